@@ -0,0 +1,216 @@
+// Package autotag suggests tags and a language for a quote. It asks the
+// client's model through MCP sampling when the client supports it, and
+// falls back to a local heuristic otherwise. Tags are always chosen among
+// the categories the API already has, so suggestions never grow the
+// category list.
+package autotag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+)
+
+// Sources of a suggestion.
+const (
+	SourceSampling  = "sampling"
+	SourceHeuristic = "heuristic"
+)
+
+// MaxTags is the most tags suggested for a quote.
+const MaxTags = 3
+
+// SamplingTimeout is how long the client's model has to answer before the
+// heuristic is used instead.
+const SamplingTimeout = 30 * time.Second
+
+// candidateWords is how many keywords of a quote are searched for
+// categories, and candidatesPerWord how many categories each may bring.
+const (
+	candidateWords    = 6
+	candidatesPerWord = 5
+)
+
+// Suggestion is the outcome of auto-tagging a quote.
+type Suggestion struct {
+	Tags []string `json:"tags,omitempty"`
+	// Language is a two-letter code, or empty when it could not be told;
+	// the API then detects it itself.
+	Language string `json:"language,omitempty"`
+	// Source tells whether the client's model or the heuristic chose.
+	Source string `json:"source"`
+	// Note explains why sampling was not used, when it was not.
+	Note string `json:"note,omitempty"`
+}
+
+// Suggest proposes tags and a language for a quote.
+func Suggest(ctx context.Context, c *client.Client, text, author string) (Suggestion, error) {
+	candidates, err := Candidates(ctx, c, text)
+	if err != nil {
+		return Suggestion{}, fmt.Errorf("searching categories: %w", err)
+	}
+	if !supportsSampling(ctx) {
+		return heuristic(text, candidates, "the client does not support sampling"), nil
+	}
+	s, err := sample(ctx, text, author, candidates)
+	if err != nil {
+		logging.Warningf(ctx, logging.LoggerTool, "Auto-tagging with sampling failed, using the heuristic: %v", err)
+		return heuristic(text, candidates, fmt.Sprintf("sampling failed: %v", err)), nil
+	}
+	return s, nil
+}
+
+// Candidates returns the existing categories matching the keywords of a
+// quote, in the order they were found.
+func Candidates(ctx context.Context, c *client.Client, text string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+	for _, word := range keywords(text, candidateWords) {
+		names, err := c.SearchCategories(ctx, word, candidatesPerWord)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			key := strings.ToLower(name)
+			if name != "" && !seen[key] {
+				seen[key] = true
+				out = append(out, name)
+			}
+		}
+	}
+	return out, nil
+}
+
+// keywords returns the longest distinct words of a quote that are not
+// stopwords, longest first.
+func keywords(text string, n int) []string {
+	seen := map[string]bool{}
+	var words []string
+	for _, w := range strings.Fields(dedupe.Normalize(text)) {
+		if len([]rune(w)) < 4 || seen[w] || isStopword(w) {
+			continue
+		}
+		seen[w] = true
+		words = append(words, w)
+	}
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+// supportsSampling reports whether the client of ctx declared sampling.
+// In HTTP mode the request is sent over the session's listening stream,
+// so a client that has none open is waited for until the timeout.
+func supportsSampling(ctx context.Context) bool {
+	if server.ServerFromContext(ctx) == nil {
+		return false
+	}
+	cs, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return false
+	}
+	if _, ok := cs.(server.SessionWithSampling); !ok {
+		return false
+	}
+	return cs.GetClientCapabilities().Sampling != nil
+}
+
+const systemPrompt = "You tag quotes for a quote collection. Answer with JSON only."
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+
+func sample(ctx context.Context, text, author string, candidates []string) (Suggestion, error) {
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Quote: %q\n", text)
+	if author != "" {
+		fmt.Fprintf(&prompt, "Author: %s\n", author)
+	}
+	if len(candidates) > 0 {
+		fmt.Fprintf(&prompt, "\nChoose up to %d tags for the quote, only from these categories: %s.\n", MaxTags, strings.Join(candidates, ", "))
+	} else {
+		prompt.WriteString("\nThere are no categories to choose tags from, so leave tags empty.\n")
+	}
+	prompt.WriteString("Also give the ISO 639-1 code of the quote's language.\n")
+	prompt.WriteString(`Answer as {"tags": ["..."], "language": "en"}.`)
+
+	ctx, cancel := context.WithTimeout(ctx, SamplingTimeout)
+	defer cancel()
+	result, err := server.ServerFromContext(ctx).RequestSampling(ctx, mcp.CreateMessageRequest{
+		CreateMessageParams: mcp.CreateMessageParams{
+			Messages: []mcp.SamplingMessage{{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(prompt.String()),
+			}},
+			SystemPrompt: systemPrompt,
+			MaxTokens:    200,
+			Temperature:  0,
+		},
+	})
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	var answer struct {
+		Tags     []string `json:"tags"`
+		Language string   `json:"language"`
+	}
+	reply := samplingText(result.Content)
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return Suggestion{}, fmt.Errorf("the model did not answer with JSON: %q", reply)
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &answer); err != nil {
+		return Suggestion{}, fmt.Errorf("the model did not answer with JSON: %w", err)
+	}
+
+	s := Suggestion{Tags: allowed(answer.Tags, candidates), Source: SourceSampling}
+	if lang := strings.ToLower(strings.TrimSpace(answer.Language)); languagePattern.MatchString(lang) {
+		s.Language = lang
+	}
+	return s, nil
+}
+
+// samplingText returns the text of a sampling answer, which arrives typed
+// or as a plain map depending on the transport.
+func samplingText(content any) string {
+	if text, ok := mcp.AsTextContent(content); ok {
+		return text.Text
+	}
+	if m, ok := content.(map[string]any); ok {
+		if text, ok := m["text"].(string); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// allowed keeps the tags that are candidates, spelled as the candidate,
+// up to MaxTags.
+func allowed(tags, candidates []string) []string {
+	byKey := map[string]string{}
+	for _, c := range candidates {
+		byKey[strings.ToLower(c)] = c
+	}
+	out := []string{}
+	seen := map[string]bool{}
+	for _, t := range tags {
+		name, ok := byKey[strings.ToLower(strings.TrimSpace(t))]
+		if ok && !seen[name] && len(out) < MaxTags {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,130 @@
+package autotag
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"The best way to predict the future is to create it.", "en"},
+		{"La vida es lo que pasa mientras haces otros planes.", "es"},
+		{"Le bonheur est la seule chose qui se double si on le partage.", "fr"},
+		{"Wer nicht wagt, der nicht gewinnt.", "de"},
+		// Too few stopwords to tell
+		{"Carpe diem.", ""},
+		{"Imagination is everything.", ""},
+		// As many stopwords of Spanish as of French
+		{"La que.", ""},
+	}
+	for _, tt := range tests {
+		if got := detectLanguage(strings.Fields(dedupe.Normalize(tt.text))); got != tt.want {
+			t.Errorf("detectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want []string
+	}{
+		{
+			text: "The journey of a thousand miles begins with a single step.",
+			n:    3,
+			want: []string{"thousand", "journey", "begins"},
+		},
+		{
+			// Long stopwords are left out, and repeated words kept once
+			text: "What you have is what you will become, become!",
+			n:    6,
+			want: []string{"become"},
+		},
+		{
+			text: "To be or not to be.",
+			n:    6,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		if got := keywords(tt.text, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("keywords(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestHeuristic(t *testing.T) {
+	candidates := []string{"Success", "Love", "Friendship", "Life", "Cat"}
+	tests := []struct {
+		text string
+		want []string
+	}{
+		// Stems match, the candidate order is kept and MaxTags is the limit
+		{"Loved friends make life sweet and success easy.", []string{"Success", "Love", "Friendship"}},
+		// "category" does not match the three-letter "cat"
+		{"Every category of life.", []string{"Life"}},
+		{"Nothing here.", []string{}},
+	}
+	for _, tt := range tests {
+		s := heuristic(tt.text, candidates, "note")
+		if !reflect.DeepEqual(s.Tags, tt.want) {
+			t.Errorf("heuristic(%q).Tags = %q, want %q", tt.text, s.Tags, tt.want)
+		}
+		if s.Source != SourceHeuristic || s.Note != "note" {
+			t.Errorf("heuristic(%q) = %+v, want source %q and the note", tt.text, s, SourceHeuristic)
+		}
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	candidates := []string{"Life", "Love", "Success", "Wisdom"}
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "spelled as the candidates",
+			tags: []string{"life", " LOVE "},
+			want: []string{"Life", "Love"},
+		},
+		{
+			name: "tags that are not candidates are dropped",
+			tags: []string{"hope", "Wisdom", "courage"},
+			want: []string{"Wisdom"},
+		},
+		{
+			name: "duplicates are kept once",
+			tags: []string{"Life", "life", "LIFE"},
+			want: []string{"Life"},
+		},
+		{
+			name: "at most MaxTags",
+			tags: []string{"wisdom", "success", "love", "life"},
+			want: []string{"Wisdom", "Success", "Love"},
+		},
+		{
+			name: "none",
+			tags: nil,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allowed(tt.tags, candidates)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allowed(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+			if len(got) > MaxTags {
+				t.Errorf("allowed(%q) returned %d tags, more than MaxTags", tt.tags, len(got))
+			}
+		})
+	}
+}
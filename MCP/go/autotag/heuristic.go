@@ -0,0 +1,104 @@
+package autotag
+
+import (
+	"strings"
+
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+)
+
+// stopwords are frequent short words of the languages the heuristic can
+// tell apart. They are not keywords and they give the language away.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "you", "that", "is", "of", "to", "in", "it", "not", "be", "are", "what", "with", "for", "your", "have", "when", "who", "will"},
+	"es": {"el", "la", "los", "las", "que", "de", "y", "en", "es", "no", "un", "una", "por", "con", "para", "lo", "se", "su", "como", "pero"},
+	"fr": {"le", "la", "les", "et", "est", "que", "qui", "de", "des", "un", "une", "pas", "ne", "dans", "pour", "vous", "il", "elle", "ce", "sur"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "ich", "du", "es", "auf", "wer", "wenn", "auch", "sie"},
+	"it": {"il", "lo", "la", "gli", "le", "che", "di", "e", "è", "non", "un", "una", "per", "con", "del", "della", "si", "chi", "come", "ma"},
+	"pt": {"o", "a", "os", "as", "que", "de", "e", "é", "não", "um", "uma", "para", "com", "do", "da", "se", "em", "por", "mas", "quem"},
+}
+
+// minLanguageHits is how many stopwords of a language a quote needs, more
+// than of any other, for the heuristic to name its language.
+const minLanguageHits = 2
+
+var stopwordSet = func() map[string]bool {
+	set := map[string]bool{}
+	for _, words := range stopwords {
+		for _, w := range words {
+			set[w] = true
+		}
+	}
+	return set
+}()
+
+func isStopword(w string) bool {
+	return stopwordSet[w]
+}
+
+// heuristic tags a quote with the candidates that appear in it, as a word
+// or the stem of one, and guesses its language from stopwords.
+func heuristic(text string, candidates []string, note string) Suggestion {
+	words := strings.Fields(dedupe.Normalize(text))
+	tags := []string{}
+	for _, c := range candidates {
+		if len(tags) == MaxTags {
+			break
+		}
+		if mentions(words, strings.ToLower(c)) {
+			tags = append(tags, c)
+		}
+	}
+	return Suggestion{Tags: tags, Language: detectLanguage(words), Source: SourceHeuristic, Note: note}
+}
+
+// mentions reports whether a word of the quote is category or shares a
+// stem of at least four letters with it, so "loved" matches "love" and
+// "friendship" matches "friends".
+func mentions(words []string, category string) bool {
+	for _, w := range words {
+		if w == category {
+			return true
+		}
+		n := min(len(w), len(category))
+		if n >= 4 && commonPrefix(w, category) >= max(4, n-2) {
+			return true
+		}
+	}
+	return false
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// detectLanguage returns the language with the most stopwords in words,
+// or "" when none clearly leads.
+func detectLanguage(words []string) string {
+	best, bestHits, runnerUp := "", 0, 0
+	for lang, list := range stopwords {
+		set := map[string]bool{}
+		for _, w := range list {
+			set[w] = true
+		}
+		hits := 0
+		for _, w := range words {
+			if set[w] {
+				hits++
+			}
+		}
+		switch {
+		case hits > bestHits:
+			best, bestHits, runnerUp = lang, hits, bestHits
+		case hits > runnerUp:
+			runnerUp = hits
+		}
+	}
+	if bestHits < minLanguageHits || bestHits == runnerUp {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,161 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// A backup is a gzipped tar archive holding manifest.json and the files
+// of uploaded backgrounds and fonts under backgrounds/ and fonts/.
+
+const (
+	ManifestFile = "manifest.json"
+	// FormatVersion is bumped when the manifest changes incompatibly.
+	FormatVersion = 1
+	// MaxEntrySize and MaxArchiveSize bound what is read from an archive,
+	// uncompressed, so a crafted one cannot exhaust memory.
+	MaxEntrySize   = 64 << 20
+	MaxArchiveSize = 512 << 20
+)
+
+// Qshow is a qshow with its quotes in order.
+type Qshow struct {
+	models.Qshow
+	QuoteIDs []string `json:"quote_ids"`
+}
+
+// Asset is a background or font with the archive path of its file. When
+// the file could not be downloaded, File is empty and Missing says why.
+type Asset struct {
+	models.Asset
+	File    string `json:"file,omitempty"`
+	SHA256  string `json:"sha256,omitempty"`
+	Missing string `json:"missing,omitempty"`
+}
+
+// Manifest describes everything in a backup.
+type Manifest struct {
+	Version     int                   `json:"version"`
+	CreatedAt   time.Time             `json:"created_at"`
+	Source      string                `json:"source"`
+	Quotes      []models.Quote        `json:"quotes"`
+	Qshows      []Qshow               `json:"qshows"`
+	QOD         []qodstore.Definition `json:"qod"`
+	Backgrounds []Asset               `json:"backgrounds"`
+	Fonts       []Asset               `json:"fonts"`
+	Warnings    []string              `json:"warnings,omitempty"`
+	// Cancelled is set when the backup was cancelled before it finished.
+	// The manifest lists what was backed up until then.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+// writeArchive writes the manifest and files to path through a temporary
+// file, so a failed backup never replaces a good one.
+func writeArchive(path string, m *Manifest, files map[string][]byte) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	err = func() error {
+		gz := gzip.NewWriter(f)
+		tw := tar.NewWriter(gz)
+		manifest, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return err
+		}
+		entries := []string{ManifestFile}
+		files[ManifestFile] = manifest
+		for _, a := range append(append([]Asset{}, m.Backgrounds...), m.Fonts...) {
+			if a.File != "" {
+				entries = append(entries, a.File)
+			}
+		}
+		for _, name := range entries {
+			data := files[name]
+			hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: m.CreatedAt}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := tw.Write(data); err != nil {
+				return err
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		return gz.Close()
+	}()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readArchive reads a backup's manifest and files.
+func readArchive(path string) (*Manifest, map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	total := int64(0)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, MaxEntrySize+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) > MaxEntrySize {
+			return nil, nil, fmt.Errorf("%s is larger than %d bytes", hdr.Name, MaxEntrySize)
+		}
+		total += int64(len(data))
+		if total > MaxArchiveSize {
+			return nil, nil, fmt.Errorf("backup archive is larger than %d bytes uncompressed", MaxArchiveSize)
+		}
+		files[hdr.Name] = data
+	}
+	raw, ok := files[ManifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("not a backup archive: no %s", ManifestFile)
+	}
+	var m Manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, nil, fmt.Errorf("reading %s: %w", ManifestFile, err)
+	}
+	if m.Version > FormatVersion {
+		return nil, nil, fmt.Errorf("backup format version %d is newer than this server supports (%d)", m.Version, FormatVersion)
+	}
+	return &m, files, nil
+}
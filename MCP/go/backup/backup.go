@@ -0,0 +1,171 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// Options control what a backup includes.
+type Options struct {
+	// Binaries includes the files of backgrounds and fonts. Without them
+	// the manifest still lists the assets, but they cannot be restored.
+	Binaries bool
+}
+
+// Create backs up the account of cfg to an archive at path. A cancelled
+// backup still writes what it collected, marked as cancelled.
+func Create(ctx context.Context, cfg *config.APIConfig, path string, opts Options) (*Manifest, error) {
+	m, files, err := Collect(ctx, cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeArchive(path, m, files); err != nil {
+		return nil, fmt.Errorf("writing archive: %w", err)
+	}
+	return m, nil
+}
+
+// Collect reads everything a backup holds from the account of cfg. The
+// files are keyed by their archive path. When the call is cancelled it
+// stops and returns what it collected so far, with the manifest marked as
+// cancelled.
+func Collect(ctx context.Context, cfg *config.APIConfig, opts Options) (*Manifest, map[string][]byte, error) {
+	c := client.New(cfg)
+	m := &Manifest{Version: FormatVersion, CreatedAt: time.Now().UTC(), Source: cfg.BaseURL}
+	files := map[string][]byte{}
+	// stopped reports whether the call was cancelled, in which case the
+	// manifest is marked so and returned as it is
+	stopped := func() bool {
+		if ctx.Err() != nil {
+			m.Cancelled = true
+		}
+		return m.Cancelled
+	}
+
+	quotes, err := c.ListPrivateQuotes(ctx, client.DefaultPageSize)
+	m.Quotes = quotes
+	if err != nil {
+		if stopped() {
+			return m, files, nil
+		}
+		return nil, nil, fmt.Errorf("listing quotes: %w", err)
+	}
+
+	qshows, err := c.ListQshows(ctx, false)
+	if err != nil {
+		if stopped() {
+			return m, files, nil
+		}
+		return nil, nil, fmt.Errorf("listing qshows: %w", err)
+	}
+	progress.Expect(ctx, len(qshows))
+	for _, q := range qshows {
+		if stopped() {
+			return m, files, nil
+		}
+		entry := Qshow{Qshow: q, QuoteIDs: []string{}}
+		_, members, err := c.QshowQuotes(ctx, q.Id)
+		if err != nil && stopped() {
+			return m, files, nil
+		}
+		if err != nil && !client.IsNotFound(err) {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("qshow %s: could not list its quotes: %v", q.Id, err))
+		}
+		for _, quote := range members {
+			entry.QuoteIDs = append(entry.QuoteIDs, quote.Id)
+		}
+		m.Qshows = append(m.Qshows, entry)
+		progress.Addf(ctx, 1, "Backed up qshow %q", q.Title)
+	}
+
+	// QOD definitions are only known from the local registry.
+	defs, err := qodstore.Open(cfg).List(c.Account())
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading QOD definition registry: %w", err)
+	}
+	m.QOD = defs
+
+	for _, kind := range []string{client.AssetBackground, client.AssetFont} {
+		assets, err := c.ListAssets(ctx, kind)
+		if err != nil {
+			if stopped() {
+				return m, files, nil
+			}
+			return nil, nil, fmt.Errorf("listing %ss: %w", kind, err)
+		}
+		progress.Expect(ctx, len(assets))
+		for _, a := range assets {
+			if stopped() {
+				return m, files, nil
+			}
+			entry := Asset{Asset: a}
+			if !opts.Binaries {
+				entry.Missing = "binaries were not included in this backup"
+			} else if data, err := c.DownloadAsset(ctx, a); err != nil {
+				if stopped() {
+					return m, files, nil
+				}
+				entry.Missing = err.Error()
+			} else {
+				entry.SHA256 = sha256Hex(data)
+				entry.File = kind + "s/" + a.Id + assetExtension(a, data)
+				files[entry.File] = data
+			}
+			if kind == client.AssetBackground {
+				m.Backgrounds = append(m.Backgrounds, entry)
+			} else {
+				m.Fonts = append(m.Fonts, entry)
+			}
+			progress.Addf(ctx, 1, "Backed up %s %s", kind, a.Id)
+		}
+	}
+
+	return m, files, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// assetExtension picks a file extension from the download URI, or from
+// the content when the URI has none.
+func assetExtension(a models.Asset, data []byte) string {
+	if u, err := url.Parse(a.DownloadURI); err == nil {
+		if ext := path.Ext(u.Path); ext != "" && len(ext) <= 6 {
+			return strings.ToLower(ext)
+		}
+	}
+	switch http.DetectContentType(data) {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "font/ttf":
+		return ".ttf"
+	case "font/otf":
+		return ".otf"
+	case "font/woff":
+		return ".woff"
+	case "font/woff2":
+		return ".woff2"
+	}
+	return ".bin"
+}
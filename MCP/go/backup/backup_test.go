@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+)
+
+func TestCollectCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pages := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/quote/list" {
+			t.Errorf("unexpected request %s after the call was cancelled", r.URL)
+			return
+		}
+		pages++
+		if pages > 1 {
+			// The call is cancelled while the second page is fetched
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		quotes := make([]string, client.DefaultPageSize)
+		for i := range quotes {
+			quotes[i] = fmt.Sprintf(`{"id":"q%d","quote":"Quote %d"}`, i, i)
+		}
+		fmt.Fprintf(w, `{"success":{"total":%d},"contents":{"quotes":[%s]}}`, 3*client.DefaultPageSize, strings.Join(quotes, ","))
+	}))
+	defer srv.Close()
+
+	m, files, err := Collect(ctx, &config.APIConfig{BaseURL: srv.URL, BearerToken: "t"}, Options{Binaries: true})
+	if err != nil {
+		t.Fatalf("Collect() error = %v, want the partial manifest", err)
+	}
+	if !m.Cancelled {
+		t.Error("Collect() manifest is not marked as cancelled")
+	}
+	if len(m.Quotes) != client.DefaultPageSize {
+		t.Errorf("Collect() kept %d quotes, want the %d of the first page", len(m.Quotes), client.DefaultPageSize)
+	}
+	if len(m.Qshows) != 0 || len(files) != 0 {
+		t.Errorf("Collect() went on after the call was cancelled: %d qshows, %d files", len(m.Qshows), len(files))
+	}
+}
@@ -0,0 +1,37 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+)
+
+// Migrate copies what the account of src has and the account of dst lacks:
+// quotes are matched by normalized text, qshows and QOD definitions by
+// title, and backgrounds and fonts by content. Nothing is written to src.
+func Migrate(ctx context.Context, src, dst *config.APIConfig, dryRun bool, kinds []string) (*Report, error) {
+	if client.New(src).Account() == client.New(dst).Account() {
+		return nil, errors.New("source and destination are the same account")
+	}
+	binaries := len(kinds) == 0
+	for _, k := range kinds {
+		binaries = binaries || k == KindBackgrounds || k == KindFonts
+	}
+	m, files, err := Collect(ctx, src, Options{Binaries: binaries})
+	if err != nil {
+		return nil, fmt.Errorf("reading source: %w", err)
+	}
+	report, err := RestoreManifest(ctx, dst, m, files, RestoreOptions{
+		DryRun:       dryRun,
+		SkipExisting: true,
+		MatchAssets:  true,
+		Kinds:        kinds,
+	})
+	if report != nil {
+		report.Destination = dst.BaseURL
+	}
+	return report, err
+}
@@ -0,0 +1,344 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// Kinds of restored entities, in restore order: assets first so nothing
+// refers to them before they exist, then quotes before the qshows that
+// contain them.
+const (
+	KindBackgrounds = "backgrounds"
+	KindFonts       = "fonts"
+	KindQuotes      = "quotes"
+	KindQshows      = "qshows"
+	KindQOD         = "qod"
+)
+
+var KindNames = []string{KindBackgrounds, KindFonts, KindQuotes, KindQshows, KindQOD}
+
+// RestoreOptions control a restore.
+type RestoreOptions struct {
+	DryRun bool
+	// SkipExisting reuses quotes with the same normalized text, qshows
+	// and QOD definitions with the same title instead of creating
+	// duplicates, so an interrupted restore can be run again.
+	SkipExisting bool
+	// MatchAssets reuses backgrounds and fonts whose content is identical
+	// to one already in the account. Every asset of the account is
+	// downloaded to compare them.
+	MatchAssets bool
+	// Kinds limits the restore to these kinds; all kinds when empty.
+	Kinds []string
+}
+
+// Failure is something from the backup that was not restored.
+type Failure struct {
+	Kind  string `json:"kind"`
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// Report is the outcome of a restore. IDMap maps the ids of the backup to
+// the ids in the target account, per kind.
+type Report struct {
+	DryRun          bool                         `json:"dry_run"`
+	Source          string                       `json:"source"`
+	Destination     string                       `json:"destination,omitempty"`
+	BackupCreatedAt time.Time                    `json:"backup_created_at"`
+	Created         map[string]int               `json:"created"`
+	Reused          map[string]int               `json:"reused"`
+	IDMap           map[string]map[string]string `json:"id_map"`
+	NotRestored     []Failure                    `json:"not_restored"`
+	// Cancelled is set when the call was cancelled before the restore
+	// finished. The report covers what was restored until then; running
+	// it again with SkipExisting restores the rest.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+func (r *Report) fail(kind, id, name string, err interface{}) {
+	r.NotRestored = append(r.NotRestored, Failure{Kind: kind, ID: id, Name: name, Error: fmt.Sprint(err)})
+}
+
+func (r *Report) mapped(kind, from, to string, reused bool) {
+	if reused {
+		r.Reused[kind]++
+	} else {
+		r.Created[kind]++
+	}
+	if to != "" {
+		r.IDMap[kind][from] = to
+	}
+}
+
+// Restore recreates the contents of the archive at path in the account of
+// cfg.
+func Restore(ctx context.Context, cfg *config.APIConfig, archive string, opts RestoreOptions) (*Report, error) {
+	m, files, err := readArchive(archive)
+	if err != nil {
+		return nil, err
+	}
+	return RestoreManifest(ctx, cfg, m, files, opts)
+}
+
+// RestoreManifest recreates m in the account of cfg, taking the asset
+// files from files by their archive path.
+func RestoreManifest(ctx context.Context, cfg *config.APIConfig, m *Manifest, files map[string][]byte, opts RestoreOptions) (*Report, error) {
+	c := client.New(cfg)
+	report := &Report{
+		DryRun:          opts.DryRun,
+		Source:          m.Source,
+		BackupCreatedAt: m.CreatedAt,
+		Created:         map[string]int{},
+		Reused:          map[string]int{},
+		IDMap:           map[string]map[string]string{},
+		NotRestored:     []Failure{},
+	}
+	for _, kind := range KindNames {
+		report.IDMap[kind] = map[string]string{}
+	}
+	want := func(kind string) bool {
+		if len(opts.Kinds) == 0 {
+			return true
+		}
+		for _, k := range opts.Kinds {
+			if k == kind {
+				return true
+			}
+		}
+		return false
+	}
+	// stopped reports whether the call was cancelled, in which case the
+	// restore stops and returns what it did so far.
+	stopped := func() bool {
+		if ctx.Err() != nil {
+			report.Cancelled = true
+		}
+		return report.Cancelled
+	}
+	for _, set := range []struct {
+		kind string
+		n    int
+	}{
+		{KindBackgrounds, len(m.Backgrounds)},
+		{KindFonts, len(m.Fonts)},
+		{KindQuotes, len(m.Quotes)},
+		{KindQshows, len(m.Qshows)},
+		{KindQOD, len(m.QOD)},
+	} {
+		if want(set.kind) {
+			progress.Expect(ctx, set.n)
+		}
+	}
+
+	for _, set := range []struct {
+		kind, asset string
+		assets      []Asset
+	}{
+		{KindBackgrounds, client.AssetBackground, m.Backgrounds},
+		{KindFonts, client.AssetFont, m.Fonts},
+	} {
+		if !want(set.kind) || stopped() {
+			continue
+		}
+		existing := map[string]string{}
+		if opts.MatchAssets {
+			hashes, err := assetHashes(ctx, c, set.asset)
+			if err != nil {
+				return report, fmt.Errorf("listing %s: %w", set.kind, err)
+			}
+			existing = hashes
+		}
+		for _, a := range set.assets {
+			if stopped() {
+				break
+			}
+			progress.Addf(ctx, 1, "Restoring %s %s", set.kind, a.Id)
+			data, ok := files[a.File]
+			if a.File == "" || !ok {
+				reason := a.Missing
+				if reason == "" {
+					reason = "file missing from archive"
+				}
+				report.fail(set.kind, a.Id, a.Name, reason)
+				continue
+			}
+			if id, ok := existing[sha256Hex(data)]; ok {
+				report.mapped(set.kind, a.Id, id, true)
+				continue
+			}
+			if opts.DryRun {
+				report.mapped(set.kind, a.Id, "", false)
+				continue
+			}
+			id, err := c.UploadAsset(ctx, set.asset, path.Base(a.File), data, a.Tags)
+			if err != nil {
+				report.fail(set.kind, a.Id, a.Name, err)
+				continue
+			}
+			report.mapped(set.kind, a.Id, id, false)
+		}
+	}
+
+	// Quotes in the backup, so qshow members that are not among them are
+	// known to be public quotes, whose ids are the same in every account.
+	private := map[string]bool{}
+	for _, q := range m.Quotes {
+		private[q.Id] = true
+	}
+	if want(KindQuotes) {
+		for _, q := range m.Quotes {
+			if stopped() {
+				break
+			}
+			progress.Addf(ctx, 1, "Restoring quote %s", q.Id)
+			if opts.SkipExisting {
+				existing, err := dedupe.FindExisting(ctx, c, q.Quote)
+				if err != nil {
+					report.fail(KindQuotes, q.Id, q.Author, fmt.Sprintf("checking for an existing quote: %v", err))
+					continue
+				}
+				if existing != nil {
+					report.mapped(KindQuotes, q.Id, existing.Id, true)
+					continue
+				}
+			}
+			if opts.DryRun {
+				report.mapped(KindQuotes, q.Id, "", false)
+				continue
+			}
+			id, err := c.CreateQuote(ctx, q)
+			if err != nil {
+				report.fail(KindQuotes, q.Id, q.Author, err)
+				continue
+			}
+			report.mapped(KindQuotes, q.Id, id, false)
+		}
+	}
+
+	if want(KindQshows) && !stopped() {
+		existing := map[string]string{}
+		if opts.SkipExisting {
+			qshows, err := c.ListQshows(ctx, false)
+			if err != nil {
+				return report, fmt.Errorf("listing qshows: %w", err)
+			}
+			for _, q := range qshows {
+				existing[dedupe.Normalize(q.Title)] = q.Id
+			}
+		}
+		for _, q := range m.Qshows {
+			if stopped() {
+				break
+			}
+			progress.Addf(ctx, 1, "Restoring qshow %q", q.Title)
+			if id, ok := existing[dedupe.Normalize(q.Title)]; ok {
+				report.mapped(KindQshows, q.Id, id, true)
+				continue
+			}
+			if q.Background != "" {
+				if bg, ok := report.IDMap[KindBackgrounds][q.Background]; ok {
+					report.fail(KindQshows, q.Id, q.Title, fmt.Sprintf("background cannot be set through the API; set it to %s by hand", bg))
+				}
+			}
+			if opts.DryRun {
+				report.mapped(KindQshows, q.Id, "", false)
+				continue
+			}
+			id, err := c.CreateQshow(ctx, q.Title, q.Description, q.Tags)
+			if err != nil {
+				report.fail(KindQshows, q.Id, q.Title, err)
+				continue
+			}
+			report.mapped(KindQshows, q.Id, id, false)
+			for _, quoteID := range q.QuoteIDs {
+				target, ok := report.IDMap[KindQuotes][quoteID]
+				if !ok {
+					if private[quoteID] {
+						report.fail(KindQshows, q.Id, q.Title, fmt.Sprintf("quote %s was not restored, so it was left out", quoteID))
+						continue
+					}
+					target = quoteID
+				}
+				if err := c.AddQshowQuote(ctx, id, target); err != nil {
+					report.fail(KindQshows, q.Id, q.Title, fmt.Sprintf("adding quote %s: %v", quoteID, err))
+				}
+			}
+		}
+	}
+
+	if want(KindQOD) {
+		store := qodstore.Open(cfg)
+		account := c.Account()
+		for _, d := range m.QOD {
+			if stopped() {
+				break
+			}
+			progress.Addf(ctx, 1, "Restoring QOD definition %q", d.Title)
+			if opts.SkipExisting {
+				existing, err := store.Find(account, d.Title)
+				if err != nil {
+					return report, fmt.Errorf("reading QOD definition registry: %w", err)
+				}
+				if existing != nil {
+					report.mapped(KindQOD, d.ID, existing.ID, true)
+					continue
+				}
+			}
+			if opts.DryRun {
+				report.mapped(KindQOD, d.ID, "", false)
+				continue
+			}
+			id, err := c.CreateQOD(ctx, client.QODFilters{
+				Title:       d.Title,
+				RepeatAfter: d.RepeatAfter,
+				Authors:     d.Authors,
+				Language:    d.Language,
+				SFW:         d.SFW,
+				Private:     d.Private,
+			})
+			if err != nil {
+				report.fail(KindQOD, d.ID, d.Title, err)
+				continue
+			}
+			if _, err := store.Update(account, id, d.Title, func(def *qodstore.Definition) {
+				def.RepeatAfter, def.Authors, def.Language = d.RepeatAfter, d.Authors, d.Language
+				def.SFW, def.Private = d.SFW, d.Private
+			}); err != nil {
+				report.fail(KindQOD, d.ID, d.Title, fmt.Sprintf("created as %s but not recorded in the registry: %v", id, err))
+			}
+			report.mapped(KindQOD, d.ID, id, false)
+		}
+	}
+	return report, nil
+}
+
+// assetHashes maps the SHA-256 of every asset of a kind in the account to
+// its id. Assets that cannot be downloaded are left out.
+func assetHashes(ctx context.Context, c *client.Client, kind string) (map[string]string, error) {
+	assets, err := c.ListAssets(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	hashes := map[string]string{}
+	for _, a := range assets {
+		data, err := c.DownloadAsset(ctx, a)
+		if err != nil {
+			continue
+		}
+		if _, ok := hashes[sha256Hex(data)]; !ok {
+			hashes[sha256Hex(data)] = a.Id
+		}
+	}
+	return hashes, nil
+}
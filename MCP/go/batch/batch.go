@@ -0,0 +1,132 @@
+package batch
+
+import (
+	"context"
+	"sync"
+
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+)
+
+// DefaultConcurrency is how many items are processed at once when the
+// caller does not say otherwise. MaxConcurrency bounds what callers may ask
+// for so a single tool call cannot flood the upstream API.
+const (
+	DefaultConcurrency = 4
+	MaxConcurrency     = 16
+)
+
+// Item statuses reported in an Outcome.
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+	StatusPlanned = "planned"
+)
+
+// MessageCancelled is the message of the items skipped because the call
+// was cancelled.
+const MessageCancelled = "cancelled"
+
+// Outcome is the per-item result of a batch operation.
+type Outcome struct {
+	ID      string   `json:"id"`
+	Status  string   `json:"status"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Summary aggregates the outcomes of a batch operation.
+type Summary struct {
+	DryRun    bool `json:"dry_run"`
+	Total     int  `json:"total"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+	Skipped   int  `json:"skipped"`
+	Planned   int  `json:"planned,omitempty"`
+	// Cancelled is set when the call was cancelled before every item was
+	// processed; the remaining items are skipped.
+	Cancelled bool      `json:"cancelled,omitempty"`
+	Items     []Outcome `json:"items"`
+}
+
+// Run calls fn for every id with at most concurrency calls in flight and
+// returns the outcomes in input order. Once ctx is cancelled the remaining
+// ids are reported as skipped without calling fn.
+func Run(ctx context.Context, ids []string, concurrency int, fn func(ctx context.Context, id string) Outcome) []Outcome {
+	concurrency = ClampConcurrency(concurrency)
+	progress.Expect(ctx, len(ids))
+	outcomes := make([]Outcome, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		select {
+		case <-ctx.Done():
+			outcomes[i] = Outcome{ID: id, Status: StatusSkipped, Message: MessageCancelled}
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			outcomes[i] = fn(ctx, id)
+			if outcomes[i].ID == "" {
+				outcomes[i].ID = id
+			}
+			progress.Addf(ctx, 1, "%s: %s", id, outcomes[i].Status)
+		}(i, id)
+	}
+	wg.Wait()
+	return outcomes
+}
+
+// ClampConcurrency maps a requested concurrency into [1, MaxConcurrency],
+// using DefaultConcurrency for non-positive values.
+func ClampConcurrency(n int) int {
+	if n <= 0 {
+		return DefaultConcurrency
+	}
+	if n > MaxConcurrency {
+		return MaxConcurrency
+	}
+	return n
+}
+
+// Summarize counts outcomes by status.
+func Summarize(outcomes []Outcome, dryRun bool) Summary {
+	s := Summary{DryRun: dryRun, Total: len(outcomes), Items: outcomes}
+	if s.Items == nil {
+		s.Items = []Outcome{}
+	}
+	for _, o := range outcomes {
+		switch o.Status {
+		case StatusOK:
+			s.Succeeded++
+		case StatusFailed:
+			s.Failed++
+		case StatusSkipped:
+			s.Skipped++
+			s.Cancelled = s.Cancelled || o.Message == MessageCancelled
+		case StatusPlanned:
+			s.Planned++
+		}
+	}
+	return s
+}
+
+// Dedupe returns ids without blanks or repeats, keeping the first
+// occurrence of each.
+func Dedupe(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,162 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+)
+
+// TagChange describes the tags to add, remove and rename on every item of
+// a batch.
+type TagChange struct {
+	Add    []string
+	Remove []string
+	Rename map[string]string // old tag -> new tag
+}
+
+// TagChangeFromRequest reads the `add`, `remove` and `rename` arguments
+// shared by the batch tag tools. `rename` is a comma separated list of
+// `old:new` pairs.
+func TagChangeFromRequest(request mcp.CallToolRequest) (TagChange, error) {
+	args := request.GetArguments()
+	change := TagChange{
+		Add:    client.Strings(args["add"]),
+		Remove: client.Strings(args["remove"]),
+		Rename: map[string]string{},
+	}
+	for _, pair := range client.Strings(args["rename"]) {
+		from, to, ok := strings.Cut(pair, ":")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return change, fmt.Errorf("invalid rename %q, expected old:new", pair)
+		}
+		change.Rename[from] = to
+	}
+	if change.Empty() {
+		return change, fmt.Errorf("at least one of add, remove or rename is required")
+	}
+	return change, nil
+}
+
+func (c TagChange) Empty() bool {
+	return len(c.Add) == 0 && len(c.Remove) == 0 && len(c.Rename) == 0
+}
+
+// Describe lists the change in words, for confirmations.
+func (c TagChange) Describe() []string {
+	var lines []string
+	if len(c.Add) > 0 {
+		lines = append(lines, "Add tags: "+strings.Join(c.Add, ", "))
+	}
+	if len(c.Remove) > 0 {
+		lines = append(lines, "Remove tags: "+strings.Join(c.Remove, ", "))
+	}
+	renames := make([]string, 0, len(c.Rename))
+	for from, to := range c.Rename {
+		renames = append(renames, from+" → "+to)
+	}
+	sort.Strings(renames)
+	if len(renames) > 0 {
+		lines = append(lines, "Rename tags: "+strings.Join(renames, ", "))
+	}
+	return lines
+}
+
+// Plan works out which tags to add and remove for an item. When the
+// item's current tags are known, tags it already has are not re-added,
+// absent tags are not removed and renames only apply to tags it carries.
+// When they are unknown (current == nil) every change is applied as given.
+func (c TagChange) Plan(current []string) (add, remove []string) {
+	has := map[string]bool{}
+	for _, t := range current {
+		has[strings.ToLower(t)] = true
+	}
+	known := current != nil
+
+	addSet, removeSet := map[string]bool{}, map[string]bool{}
+	queueAdd := func(tag string) {
+		if !addSet[tag] && (!known || !has[strings.ToLower(tag)]) {
+			addSet[tag] = true
+			add = append(add, tag)
+		}
+	}
+	queueRemove := func(tag string) {
+		if !removeSet[tag] && (!known || has[strings.ToLower(tag)]) {
+			removeSet[tag] = true
+			remove = append(remove, tag)
+		}
+	}
+
+	froms := make([]string, 0, len(c.Rename))
+	for from := range c.Rename {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		to := c.Rename[from]
+		if known && !has[strings.ToLower(from)] {
+			continue
+		}
+		queueRemove(from)
+		queueAdd(to)
+	}
+	for _, tag := range c.Remove {
+		queueRemove(tag)
+	}
+	for _, tag := range c.Add {
+		queueAdd(tag)
+	}
+	return add, remove
+}
+
+// TagEndpoints are the add/remove paths for one kind of taggable entity,
+// e.g. "/quote/tags/add" and "/quote/tags/remove".
+type TagEndpoints struct {
+	Add    string
+	Remove string
+}
+
+var (
+	QuoteTagEndpoints      = TagEndpoints{Add: "/quote/tags/add", Remove: "/quote/tags/remove"}
+	BackgroundTagEndpoints = TagEndpoints{Add: "/quote/image/background/tags/add", Remove: "/quote/image/background/tags/remove"}
+	FontTagEndpoints       = TagEndpoints{Add: "/quote/image/font/tags/add", Remove: "/quote/image/font/tags/remove"}
+)
+
+// ApplyTags plans change against current and, unless dryRun is set, sends
+// the removals and additions for id.
+func ApplyTags(ctx context.Context, c *client.Client, endpoints TagEndpoints, id string, current []string, change TagChange, dryRun bool) Outcome {
+	add, remove := change.Plan(current)
+	outcome := Outcome{ID: id, Added: add, Removed: remove}
+	if len(add) == 0 && len(remove) == 0 {
+		outcome.Status = StatusSkipped
+		outcome.Message = "no tag changes needed"
+		return outcome
+	}
+	if dryRun {
+		outcome.Status = StatusPlanned
+		return outcome
+	}
+	if len(remove) > 0 {
+		if _, err := c.Post(ctx, endpoints.Remove, url.Values{"id": {id}, "tags": {strings.Join(remove, ",")}}); err != nil {
+			outcome.Status = StatusFailed
+			outcome.Added = nil
+			outcome.Error = fmt.Sprintf("removing tags: %v", err)
+			return outcome
+		}
+	}
+	if len(add) > 0 {
+		if _, err := c.Post(ctx, endpoints.Add, url.Values{"id": {id}, "tags": {strings.Join(add, ",")}}); err != nil {
+			outcome.Status = StatusFailed
+			outcome.Added = nil
+			outcome.Error = fmt.Sprintf("adding tags: %v", err)
+			return outcome
+		}
+	}
+	outcome.Status = StatusOK
+	return outcome
+}
@@ -0,0 +1,70 @@
+// Package capabilities names what credentials may allow, such as reaching
+// a private collection or detailed author information, so the server only
+// exposes the tools and arguments that can work for them. The capstore
+// package keeps track of the capabilities of each session.
+package capabilities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Capabilities credentials may have.
+const (
+	// Authenticated means the API accepts the credentials.
+	Authenticated = "authenticated"
+	// Private means the private collection can be read and changed.
+	Private = "private"
+	// PrivateQOD means private QOD services can be created. It cannot be
+	// probed without creating one, so it follows Private.
+	PrivateQOD = "private_qod"
+	// DetailedAuthors means author searches can return biographies.
+	DetailedAuthors = "detailed_authors"
+	// Branding means quote images can be made without branding. It cannot
+	// be probed without creating an image, so it follows Authenticated.
+	Branding = "branding"
+)
+
+// All lists every capability.
+var All = []string{Authenticated, Private, PrivateQOD, DetailedAuthors, Branding}
+
+// Set tells which capabilities credentials have. A nil Set has them all,
+// for callers that do not filter tools, such as the CLI commands.
+type Set map[string]bool
+
+// Has reports whether s includes capability.
+func (s Set) Has(capability string) bool {
+	return s == nil || s[capability]
+}
+
+func (s Set) String() string {
+	var names []string
+	for _, name := range All {
+		if s.Has(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// Parse reads a comma-separated list of capabilities forced on, or off when
+// prefixed with "-". Capabilities that are not listed are probed.
+func Parse(spec string) (map[string]bool, error) {
+	forced := map[string]bool{}
+	for _, name := range strings.Split(strings.ToLower(spec), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		name, off := strings.CutPrefix(name, "-")
+		if !slices.Contains(All, name) {
+			return nil, fmt.Errorf("unknown capability %q: expected %s", name, strings.Join(All, ", "))
+		}
+		forced[name] = !off
+	}
+	return forced, nil
+}
@@ -0,0 +1,209 @@
+// Package capstore tracks what the credentials of each session allow.
+//
+// The capabilities are probed once per account and shared by every
+// session using it. They are probed again every ProbeTTL, and sessions
+// whose capabilities change, because the account's subscription did or
+// because the session switched credentials, are told to list the tools
+// again.
+package capstore
+
+import (
+	"context"
+	"log"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
+)
+
+const (
+	// ProbeTTL is how long probed capabilities are trusted before they are
+	// probed again.
+	ProbeTTL = 15 * time.Minute
+	// RetryInterval is how soon a probe that failed is tried again, and
+	// how often probes are checked for expiry.
+	RetryInterval = time.Minute
+	// ProbeTimeout bounds the upstream calls of a probe.
+	ProbeTimeout = 10 * time.Second
+)
+
+// Probe finds the capabilities of an account. When it fails it still
+// returns its best guess, which assumes what could not be probed is
+// available, so a transient failure hides nothing.
+type Probe func(ctx context.Context) (capabilities.Set, error)
+
+// Store caches the capabilities of each account and remembers those each
+// session of a server was shown.
+type Store struct {
+	mu       sync.Mutex
+	srv      *server.MCPServer
+	accounts map[string]*account
+	sessions map[string]*session
+}
+
+type account struct {
+	set     capabilities.Set
+	probe   Probe
+	expires time.Time
+}
+
+type session struct {
+	account string
+	set     capabilities.Set
+}
+
+func NewStore() *Store {
+	return &Store{accounts: map[string]*account{}, sessions: map[string]*session{}}
+}
+
+// Get returns the capabilities of an account, probing them the first time.
+// Expired capabilities are returned as they are and probed again by Poll.
+func (st *Store) Get(ctx context.Context, id string, probe Probe) capabilities.Set {
+	st.mu.Lock()
+	if a, ok := st.accounts[id]; ok {
+		a.probe = probe
+		st.mu.Unlock()
+		return a.set
+	}
+	st.mu.Unlock()
+
+	set, expires := run(ctx, id, probe)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.accounts[id] = &account{set: set, probe: probe, expires: expires}
+	return set
+}
+
+// run probes an account and returns when to probe it again.
+func run(ctx context.Context, id string, probe Probe) (capabilities.Set, time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+	set, err := probe(ctx)
+	if err != nil {
+		log.Printf("Probing the capabilities of account %s: %v; assuming %s", id, err, set)
+		return set, time.Now().Add(RetryInterval)
+	}
+	return set, time.Now().Add(ProbeTTL)
+}
+
+// Attach makes s record the capabilities its sessions were shown, and
+// tell them to list tools, prompts and resource templates again when those
+// change. account names the account of a request and how to probe it, or
+// returns an empty id when the request has none. A session that comes
+// back with other credentials, which HTTP headers allow, is told so too.
+// hooks must be the hooks s was created with.
+func (st *Store) Attach(s *server.MCPServer, hooks *server.Hooks, account func(ctx context.Context) (string, Probe)) {
+	st.mu.Lock()
+	st.srv = s
+	st.mu.Unlock()
+	hooks.AddOnRegisterSession(func(ctx context.Context, cs server.ClientSession) {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		st.sessions[cs.SessionID()] = &session{}
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, cs server.ClientSession) {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		delete(st.sessions, cs.SessionID())
+	})
+	hooks.AddBeforeAny(func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
+		cs := server.ClientSessionFromContext(ctx)
+		id, probe := account(ctx)
+		if cs == nil || id == "" {
+			return
+		}
+		set := st.Get(ctx, id, probe)
+		st.mu.Lock()
+		sess, ok := st.sessions[cs.SessionID()]
+		changed := ok && sess.set != nil && (sess.account != id || !maps.Equal(sess.set, set))
+		if ok {
+			sess.account, sess.set = id, set
+		}
+		st.mu.Unlock()
+		if changed {
+			st.notify(cs.SessionID())
+		}
+	})
+}
+
+// notify tells a session to list what depends on its capabilities again.
+func (st *Store) notify(id string) {
+	st.mu.Lock()
+	srv := st.srv
+	st.mu.Unlock()
+	for _, method := range []string{
+		mcp.MethodNotificationToolsListChanged,
+		mcp.MethodNotificationPromptsListChanged,
+		mcp.MethodNotificationResourcesListChanged,
+	} {
+		if err := srv.SendNotificationToSpecificClient(id, method, nil); err != nil {
+			log.Printf("Telling session %s its capabilities changed: %v", id, err)
+			return
+		}
+	}
+}
+
+// Run probes expired capabilities every RetryInterval until ctx is done.
+func (st *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(RetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			st.Poll(ctx)
+		}
+	}
+}
+
+// Poll probes again the expired capabilities of accounts that sessions
+// use and tells the sessions whose capabilities changed. Accounts no
+// session uses are dropped.
+func (st *Store) Poll(ctx context.Context) {
+	st.mu.Lock()
+	used := map[string]bool{}
+	for _, sess := range st.sessions {
+		used[sess.account] = true
+	}
+	expired := map[string]Probe{}
+	for id, a := range st.accounts {
+		switch {
+		case !used[id]:
+			delete(st.accounts, id)
+		case time.Now().After(a.expires):
+			expired[id] = a.probe
+		}
+	}
+	st.mu.Unlock()
+
+	for id, probe := range expired {
+		set, expires := run(ctx, id, probe)
+
+		st.mu.Lock()
+		a, ok := st.accounts[id]
+		if !ok {
+			st.mu.Unlock()
+			continue
+		}
+		changed := !maps.Equal(a.set, set)
+		a.set, a.expires = set, expires
+		var notify []string
+		if changed {
+			log.Printf("Capabilities of account %s changed to %s", id, set)
+			for sid, sess := range st.sessions {
+				if sess.account == id {
+					sess.set = set
+					notify = append(notify, sid)
+				}
+			}
+		}
+		st.mu.Unlock()
+		for _, sid := range notify {
+			st.notify(sid)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	tools_private_quotes "github.com/they-said-so-quotes-api/mcp-server/tools/private_quotes"
+)
+
+// commands maps CLI subcommands to the tool they run. Flags become tool
+// arguments, so `mcp-server export --format csv --path quotes.csv` is the
+// same as calling export_quotes_file with {"format": "csv", "path": "quotes.csv"}.
+var commands = map[string]string{
+	"export":  "export_quotes_file",
+	"sync":    "sync_collection",
+	"backup":  "backup_account",
+	"restore": "restore_account",
+	"migrate": "migrate_account",
+}
+
+// runCommand runs a CLI subcommand and returns the process exit code.
+func runCommand(cfg *config.APIConfig, name string, args []string) int {
+	toolName, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		return 2
+	}
+	arguments, err := parseFlags(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 2
+	}
+	// Commands are run by the user of the machine, so their paths are
+	// relative to the working directory rather than the data directory,
+	// and they may reach other API hosts with the credentials they are given
+	cfg.FileRoot = "."
+	cfg.OtherHosts = true
+	// Without --path the export is returned inline and printed
+	if toolName == "export_quotes_file" && arguments["path"] == nil {
+		toolName = "export_quotes"
+	}
+
+	for _, tool := range GetAll(cfg, nil) {
+		if tool.Definition.Name != toolName {
+			continue
+		}
+		req := mcp.CallToolRequest{}
+		req.Params.Name = toolName
+		req.Params.Arguments = arguments
+		result, err := tool.Handler(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			return 1
+		}
+		out := os.Stdout
+		if result.IsError {
+			out = os.Stderr
+		}
+		if export, ok := result.StructuredContent.(tools_private_quotes.ExportSummary); ok && export.Data != "" {
+			fmt.Fprint(out, export.Data)
+			return 0
+		}
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				fmt.Fprint(out, text.Text)
+				if !strings.HasSuffix(text.Text, "\n") {
+					fmt.Fprintln(out)
+				}
+			}
+		}
+		if result.IsError {
+			return 1
+		}
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "%s: tool %q is not enabled; check TOOLSETS and READ_ONLY\n", name, toolName)
+	return 1
+}
+
+// parseFlags turns `--key value`, `--key=value` and bare `--switch` flags
+// into tool arguments. Repeated flags become lists and values that look
+// like JSON arrays or objects are decoded.
+func parseFlags(args []string) (map[string]any, error) {
+	arguments := map[string]any{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			return nil, fmt.Errorf("unexpected argument %q", arg)
+		}
+		key, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !hasValue {
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				value = args[i+1]
+				i++
+			} else {
+				value = "true"
+			}
+		}
+		key = strings.ReplaceAll(key, "-", "_")
+
+		var parsed any = value
+		if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+			if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+				return nil, fmt.Errorf("invalid JSON for --%s: %w", key, err)
+			}
+		}
+		switch existing := arguments[key].(type) {
+		case nil:
+			arguments[key] = parsed
+		case []any:
+			arguments[key] = append(existing, parsed)
+		default:
+			arguments[key] = []any{existing, parsed}
+		}
+	}
+	return arguments, nil
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "usage: mcp-server [command] [--flag value ...]")
+	fmt.Fprintln(os.Stderr, "\nWithout a command the MCP server is started. Commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s runs the %s tool\n", name, commands[name])
+	}
+}
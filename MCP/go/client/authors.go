@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// IsTierError reports whether err is the API refusing a feature the
+// subscription does not include.
+func IsTierError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusPaymentRequired, http.StatusForbidden:
+		return true
+	}
+	return false
+}
+
+// SearchAuthors searches author names in a language. A 404 means no author
+// matched and is returned as an empty result.
+func (c *Client) SearchAuthors(ctx context.Context, query, language string, detailed bool, limit int) ([]models.Author, error) {
+	params := url.Values{"query": {query}, "limit": {strconv.Itoa(limit)}}
+	if language != "" {
+		params.Set("language", language)
+	}
+	if detailed {
+		params.Set("detailed", "true")
+	}
+	result, err := c.Get(ctx, "/quote/authors/search", params)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Authors(result), nil
+}
+
+// Authors returns the authors found under contents.authors.
+func Authors(result map[string]interface{}) []models.Author {
+	var list models.AuthorList
+	models.FromMap(Contents(result), &list)
+	if list.Authors == nil {
+		return []models.Author{}
+	}
+	return list.Authors
+}
+
+// Languages returns the languages supported by the platform, as listed by
+// /qod/languages.
+func (c *Client) Languages(ctx context.Context) ([]string, error) {
+	result, err := c.Get(ctx, "/qod/languages", nil)
+	if err != nil {
+		return nil, err
+	}
+	return Strings(Contents(result)["languages"]), nil
+}
+
+// SearchQuoteImages searches quote images. A 404 means nothing matched and
+// is returned as an empty result.
+func (c *Client) SearchQuoteImages(ctx context.Context, params url.Values) ([]models.QuoteImage, error) {
+	result, err := c.Get(ctx, "/quote/image/search", params)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return QuoteImages(result), nil
+}
+
+// QuoteImages returns the quote images of a listing response.
+func QuoteImages(result map[string]interface{}) []models.QuoteImage {
+	images := []models.QuoteImage{}
+	for _, m := range Items(result) {
+		images = append(images, quoteImageFromMap(m))
+	}
+	return images
+}
+
+func quoteImageFromMap(m map[string]interface{}) models.QuoteImage {
+	var image models.QuoteImage
+	models.FromMap(m, &image)
+	return image
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// PrivateQuotesTTL is how long a listing of the private collection is
+// reused before it is fetched again.
+const PrivateQuotesTTL = 5 * time.Minute
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// Cache is a small TTL cache shared by every Client. In HTTP mode a new
+// server and client are built for each request, so the cache lives at
+// package level and entries are keyed per account.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCache() *Cache {
+	return &Cache{entries: map[string]cacheEntry{}}
+}
+
+// SharedCache is the cache used by clients created with New.
+var SharedCache = NewCache()
+
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{value: value, expires: time.Now().Add(ttl)}
+}
+
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Account identifies the client's base URL and credentials. The
+// credentials are hashed so they never sit in caches or local state in
+// clear text.
+func (c *Client) Account() string {
+	sum := sha256.Sum256([]byte(c.cfg.BaseURL + "\x00" + c.cfg.BearerToken))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cacheKey scopes a cache key to the client's account.
+func (c *Client) cacheKey(parts ...string) string {
+	return c.Account() + ":" + strings.Join(parts, ":")
+}
+
+// CachedPrivateQuotes returns the private collection, reusing a listing
+// fetched within PrivateQuotesTTL.
+func (c *Client) CachedPrivateQuotes(ctx context.Context) ([]models.Quote, error) {
+	key := c.cacheKey("private-quotes")
+	if v, ok := c.cache.Get(key); ok {
+		logging.Debugf(ctx, logging.LoggerCache, "Reusing cached listing of the private collection")
+		return v.([]models.Quote), nil
+	}
+	quotes, err := c.ListPrivateQuotes(ctx, DefaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, quotes, PrivateQuotesTTL)
+	return quotes, nil
+}
+
+// InvalidatePrivateQuotes drops the cached private collection, e.g. after
+// a quote was created or edited.
+func (c *Client) InvalidatePrivateQuotes() {
+	c.cache.Delete(c.cacheKey("private-quotes"))
+}
+
+// Cached returns the value stored under key for the client's account, or
+// fetches it and keeps it for ttl. Errors are not cached.
+func (c *Client) Cached(ctx context.Context, key string, ttl time.Duration, fetch func() (interface{}, error)) (interface{}, error) {
+	if v, ok := c.cache.Get(c.cacheKey(key)); ok {
+		logging.Debugf(ctx, logging.LoggerCache, "Reusing cached %s", key)
+		return v, nil
+	}
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(c.cacheKey(key), v, ttl)
+	return v, nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
+)
+
+// probeAuthor is searched for to probe detailed author information; any
+// answer but a refusal will do.
+const probeAuthor = "shakespeare"
+
+// Capabilities probes what the client's credentials allow, with the
+// cheapest reads that tell: one quote of the private collection and one
+// detailed author. Capabilities the configuration forces are not probed.
+// When a probe fails, what it would have told is assumed available.
+func (c *Client) Capabilities(ctx context.Context) (capabilities.Set, error) {
+	forced := c.cfg.Capabilities
+	isForced := func(name string) bool {
+		_, ok := forced[name]
+		return ok
+	}
+	set := capabilities.Set{}
+	var errs []error
+
+	if c.cfg.BearerToken != "" && !(isForced(capabilities.Authenticated) && isForced(capabilities.Private)) {
+		_, err := c.Get(ctx, "/quote/list", url.Values{"limit": {"1"}})
+		var apiErr *APIError
+		switch {
+		case err == nil || IsNotFound(err):
+			set[capabilities.Authenticated], set[capabilities.Private] = true, true
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+			// The credentials were rejected
+		case IsTierError(err):
+			set[capabilities.Authenticated] = true
+		default:
+			set[capabilities.Authenticated], set[capabilities.Private] = true, true
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range []string{capabilities.Authenticated, capabilities.Private} {
+		if isForced(name) {
+			set[name] = forced[name]
+		}
+	}
+
+	if set[capabilities.Authenticated] && !isForced(capabilities.DetailedAuthors) {
+		_, err := c.SearchAuthors(ctx, probeAuthor, "", true, 1)
+		switch {
+		case err == nil:
+			set[capabilities.DetailedAuthors] = true
+		case IsTierError(err):
+		default:
+			set[capabilities.DetailedAuthors] = true
+			errs = append(errs, err)
+		}
+	}
+
+	set[capabilities.PrivateQOD] = set[capabilities.Private]
+	set[capabilities.Branding] = set[capabilities.Authenticated]
+	for name, on := range forced {
+		set[name] = on
+	}
+	return set, errors.Join(errs...)
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+)
+
+// DefaultPageSize is the page size used when walking paged listings.
+const DefaultPageSize = 50
+
+// Client performs authenticated calls against the They Said So API.
+// Tools that compose several upstream calls use it instead of building
+// each request by hand.
+type Client struct {
+	cfg   *config.APIConfig
+	http  *http.Client
+	cache *Cache
+}
+
+// APIError is returned when the upstream API answers with a 4xx/5xx status.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// Detail is the decoded error envelope, when the body is one.
+	Detail models.ErrorDetail
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Body: string(body)}
+	var resp models.ErrorResponse
+	if json.Unmarshal(body, &resp) == nil {
+		e.Detail = resp.Error
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.Detail.Message != "" {
+		return fmt.Sprintf("API error: %s", e.Detail.Message)
+	}
+	return fmt.Sprintf("API error: %s", e.Body)
+}
+
+// IsNotFound reports whether err is a 404 from the API. Search endpoints
+// answer 404 when nothing matches.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func New(cfg *config.APIConfig) *Client {
+	return &Client{cfg: cfg, http: HTTPClient, cache: SharedCache}
+}
+
+// Config returns the configuration the client was created with.
+func (c *Client) Config() *config.APIConfig {
+	return c.cfg
+}
+
+// Do sends a request to path (relative to the configured base URL) and
+// decodes the JSON response body.
+func (c *Client) Do(ctx context.Context, method, path string, params url.Values) (map[string]interface{}, error) {
+	body, err := c.DoRaw(ctx, method, path, params)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(body)
+}
+
+// DoRaw sends a request and returns the undecoded response body.
+func (c *Client) DoRaw(ctx context.Context, method, path string, params url.Values) ([]byte, error) {
+	u := c.cfg.BaseURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.cfg.BearerToken))
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+func (c *Client) Get(ctx context.Context, path string, params url.Values) (map[string]interface{}, error) {
+	return c.Do(ctx, http.MethodGet, path, params)
+}
+
+func (c *Client) Post(ctx context.Context, path string, params url.Values) (map[string]interface{}, error) {
+	return c.Do(ctx, http.MethodPost, path, params)
+}
+
+func (c *Client) Put(ctx context.Context, path string, params url.Values) (map[string]interface{}, error) {
+	return c.Do(ctx, http.MethodPut, path, params)
+}
+
+func (c *Client) Patch(ctx context.Context, path string, params url.Values) (map[string]interface{}, error) {
+	return c.Do(ctx, http.MethodPatch, path, params)
+}
+
+func (c *Client) Delete(ctx context.Context, path string, params url.Values) (map[string]interface{}, error) {
+	return c.Do(ctx, http.MethodDelete, path, params)
+}
+
+// ListPrivateQuotes walks every page of /quote/list and returns the whole
+// private collection. pageSize <= 0 uses DefaultPageSize.
+func (c *Client) ListPrivateQuotes(ctx context.Context, pageSize int) ([]models.Quote, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	var quotes []models.Quote
+	for start := 0; ; start += pageSize {
+		params := url.Values{}
+		params.Set("start", strconv.Itoa(start))
+		params.Set("limit", strconv.Itoa(pageSize))
+		result, err := c.Get(ctx, "/quote/list", params)
+		if err != nil {
+			return quotes, err
+		}
+		page := Quotes(result)
+		quotes = append(quotes, page...)
+		if start == 0 {
+			progress.Expect(ctx, Total(result))
+		}
+		progress.Addf(ctx, len(page), "Fetched %d quotes", len(quotes))
+		if len(page) < pageSize {
+			return quotes, nil
+		}
+		if total := Total(result); total > 0 && start+pageSize >= total {
+			return quotes, nil
+		}
+	}
+}
+
+// SearchQuotes calls /quote/search with params and returns the matches.
+func (c *Client) SearchQuotes(ctx context.Context, params url.Values) ([]models.Quote, error) {
+	result, err := c.Get(ctx, "/quote/search", params)
+	if err != nil {
+		return nil, err
+	}
+	return Quotes(result), nil
+}
+
+// SearchCategories returns the names of quote categories matching query.
+// A 404 means nothing matched and is returned as an empty result.
+func (c *Client) SearchCategories(ctx context.Context, query string, limit int) ([]string, error) {
+	result, err := c.Get(ctx, "/quote/categories/search", url.Values{"query": {query}, "limit": {strconv.Itoa(limit)}})
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	categories := Categories(result)
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	return names, nil
+}
+
+// GetQuote fetches a single quote by id.
+func (c *Client) GetQuote(ctx context.Context, id string) (models.Quote, error) {
+	result, err := c.Get(ctx, "/quote", url.Values{"id": {id}})
+	if err != nil {
+		return models.Quote{}, err
+	}
+	q := FirstQuote(result)
+	if q.Id == "" {
+		q.Id = id
+	}
+	return q, nil
+}
+
+// PatchQuote updates the given fields of a private quote. Tags are sent as
+// a comma separated list.
+func (c *Client) PatchQuote(ctx context.Context, id string, fields url.Values) error {
+	params := url.Values{"id": {id}}
+	for k, v := range fields {
+		params[k] = v
+	}
+	if tags, ok := fields["tags"]; ok {
+		params.Set("tags", strings.Join(tags, ","))
+	}
+	_, err := c.Patch(ctx, "/quote", params)
+	if err == nil {
+		c.InvalidatePrivateQuotes()
+	}
+	return err
+}
+
+// CreateQuote adds a quote to the private collection and returns its id.
+func (c *Client) CreateQuote(ctx context.Context, q models.Quote) (string, error) {
+	params := url.Values{"quote": {q.Quote}}
+	if q.Author != "" {
+		params.Set("author", q.Author)
+	}
+	if q.Language != "" {
+		params.Set("language", q.Language)
+	}
+	if len(q.Tags) > 0 {
+		params.Set("tags", strings.Join(q.Tags, ","))
+	}
+	result, err := c.Put(ctx, "/quote", params)
+	if err != nil {
+		return "", err
+	}
+	c.InvalidatePrivateQuotes()
+	id := ID(result)
+	if id == "" {
+		return "", fmt.Errorf("quote created but no id found in response")
+	}
+	return id, nil
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// The API is loose with types: numeric fields such as `length` and
+// `total` arrive as either JSON numbers or strings, and mutation responses
+// use `content` where reads use `contents`. The helpers below read
+// decoded responses into the models, which decode leniently.
+
+// Decode decodes a JSON response body. An empty body, as some mutations
+// answer with, decodes to an empty object.
+func Decode(body []byte) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result, nil
+}
+
+// Contents returns the `contents` (or `content`) object of a response.
+func Contents(result map[string]interface{}) map[string]interface{} {
+	for _, key := range []string{"contents", "content"} {
+		if m, ok := result[key].(map[string]interface{}); ok {
+			return m
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// Total returns success.total, or 0 when it is absent.
+func Total(result map[string]interface{}) int {
+	success, _ := result["success"].(map[string]interface{})
+	return Int(success["total"])
+}
+
+// Quotes returns the quotes found under contents.quotes.
+func Quotes(result map[string]interface{}) []models.Quote {
+	var list models.QuoteList
+	models.FromMap(Contents(result), &list)
+	if list.Quotes == nil {
+		return []models.Quote{}
+	}
+	return list.Quotes
+}
+
+// FirstQuote returns the quote of a single quote response, found either as
+// the first of contents.quotes or as contents itself.
+func FirstQuote(result map[string]interface{}) models.Quote {
+	if quotes := Quotes(result); len(quotes) > 0 {
+		return quotes[0]
+	}
+	return QuoteFromMap(Contents(result))
+}
+
+// Categories returns the categories found under contents.categories, in
+// any of the formats models.Categories accepts.
+func Categories(result map[string]interface{}) []models.Category {
+	var list models.CategoryList
+	models.FromMap(Contents(result), &list)
+	if list.Categories == nil {
+		return []models.Category{}
+	}
+	return list.Categories
+}
+
+// StatusFromResult returns the id and message of a mutation response.
+func StatusFromResult(result map[string]interface{}) models.Status {
+	var status models.Status
+	models.FromMap(Contents(result), &status)
+	status.Id = ID(result)
+	return status
+}
+
+// ToggleFromResult returns the outcome of a like or bookmark toggle.
+func ToggleFromResult(result map[string]interface{}) models.Toggle {
+	var toggle models.Toggle
+	models.FromMap(Contents(result), &toggle)
+	return toggle
+}
+
+// QuoteFromMap converts a decoded quote object into a models.Quote.
+func QuoteFromMap(m map[string]interface{}) models.Quote {
+	var q models.Quote
+	models.FromMap(m, &q)
+	return q
+}
+
+// ID returns the id of a created or updated entity. Depending on the
+// endpoint it is found at contents.id or nested one level deeper
+// (e.g. content.quote.id), or on the first item of a contents list.
+func ID(result map[string]interface{}) string {
+	contents := Contents(result)
+	if items, ok := result["contents"].([]interface{}); ok && len(items) > 0 {
+		contents, _ = items[0].(map[string]interface{})
+	}
+	if id := String(contents["id"]); id != "" {
+		return id
+	}
+	for _, v := range contents {
+		if m, ok := v.(map[string]interface{}); ok {
+			if id := String(m["id"]); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// String converts a decoded JSON scalar to a string. nil becomes "".
+func String(v interface{}) string {
+	return models.String(v)
+}
+
+// Int converts a decoded JSON number or numeric string to an int.
+func Int(v interface{}) int {
+	return models.Int(v)
+}
+
+// Strings converts a decoded JSON array (or a comma separated string) to
+// a string slice.
+func Strings(v interface{}) []string {
+	return models.Strings(v)
+}
+
+// SplitList splits a comma separated list, trimming blanks.
+func SplitList(s string) []string {
+	return models.SplitList(s)
+}
+
+// SearchKeys are the /quote/search parameters composite tools accept as a
+// search spec.
+var SearchKeys = []string{"category", "author", "query", "language", "minlength", "maxlength", "sfw", "limit"}
+
+// SearchParams copies the SearchKeys present in args into query
+// parameters. It returns nil when args carry no search filter at all.
+func SearchParams(args map[string]any) url.Values {
+	params := url.Values{}
+	for _, key := range SearchKeys {
+		if v, ok := args[key]; ok && v != nil && String(v) != "" {
+			params.Set(key, String(v))
+		}
+	}
+	if len(params) == 0 || (len(params) == 1 && params.Has("limit")) {
+		return nil
+	}
+	return params
+}
+
+// Items returns the objects of a listing response. Listings put them in an
+// array under contents (the key differs per endpoint), directly as the
+// contents array, or as a single object such as contents.qimage.
+func Items(result map[string]interface{}) []map[string]interface{} {
+	var items []map[string]interface{}
+	collect := func(v interface{}) {
+		switch val := v.(type) {
+		case []interface{}:
+			for _, item := range val {
+				if m, ok := item.(map[string]interface{}); ok {
+					items = append(items, m)
+				}
+			}
+		case map[string]interface{}:
+			if String(val["id"]) != "" {
+				items = append(items, val)
+			}
+		}
+	}
+	if list, ok := result["contents"].([]interface{}); ok {
+		collect(list)
+		return items
+	}
+	contents := Contents(result)
+	keys := make([]string, 0, len(contents))
+	for k := range contents {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		collect(contents[k])
+	}
+	return items
+}
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+)
+
+func assetFromMap(m map[string]interface{}) models.Asset {
+	var a models.Asset
+	models.FromMap(m, &a)
+	return a
+}
+
+// Image asset kinds, as used in the /quote/image/{kind} paths.
+const (
+	AssetBackground = "background"
+	AssetFont       = "font"
+)
+
+// SearchAssets searches backgrounds or fonts by tag. A 404 means nothing
+// matched and is returned as an empty result.
+func (c *Client) SearchAssets(ctx context.Context, kind, query string) ([]models.Asset, error) {
+	result, err := c.Get(ctx, "/quote/image/"+kind+"/search", url.Values{"query": {query}})
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Assets(result), nil
+}
+
+// Assets returns the backgrounds or fonts of a listing response.
+func Assets(result map[string]interface{}) []models.Asset {
+	assets := []models.Asset{}
+	for _, m := range Items(result) {
+		if a := assetFromMap(m); a.Id != "" {
+			assets = append(assets, a)
+		}
+	}
+	return assets
+}
+
+// CreateQuoteImage renders a quote image with PUT /quote/image and returns
+// its id.
+func (c *Client) CreateQuoteImage(ctx context.Context, params url.Values) (string, error) {
+	result, err := c.Put(ctx, "/quote/image", params)
+	if err != nil {
+		return "", err
+	}
+	id := ID(result)
+	if id == "" {
+		return "", fmt.Errorf("quote image created but no id found in response")
+	}
+	return id, nil
+}
+
+// GetQuoteImage returns the metadata of a quote image.
+func (c *Client) GetQuoteImage(ctx context.Context, id string) (models.QuoteImage, error) {
+	result, err := c.Get(ctx, "/quote/image", url.Values{"id": {id}, "binary": {"false"}})
+	if err != nil {
+		return models.QuoteImage{}, err
+	}
+	image := QuoteImageFromResult(result)
+	image.Id = id
+	return image, nil
+}
+
+// QuoteImageFromResult returns the quote image of a /quote/image response.
+// The inline image may come inside qimage or next to it.
+func QuoteImageFromResult(result map[string]interface{}) models.QuoteImage {
+	m, ok := Contents(result)["qimage"].(map[string]interface{})
+	if !ok {
+		return quoteImageFromMap(Contents(result))
+	}
+	image := quoteImageFromMap(m)
+	if image.Image == "" {
+		image.Image = quoteImageFromMap(Contents(result)).Image
+	}
+	return image
+}
+
+// QuoteImageData downloads the image file of a quote image.
+func (c *Client) QuoteImageData(ctx context.Context, id string) ([]byte, error) {
+	return c.DoRaw(ctx, http.MethodGet, "/quote/image", url.Values{"id": {id}, "binary": {"true"}})
+}
+
+// ListAssets walks every page of the private background or font listing.
+func (c *Client) ListAssets(ctx context.Context, kind string) ([]models.Asset, error) {
+	var assets []models.Asset
+	for start := 0; ; {
+		result, err := c.Get(ctx, "/quote/image/"+kind+"/list", url.Values{"start": {strconv.Itoa(start)}})
+		if IsNotFound(err) {
+			return assets, nil
+		}
+		if err != nil {
+			return assets, err
+		}
+		items := Items(result)
+		assets = append(assets, Assets(result)...)
+		if start == 0 {
+			progress.Expect(ctx, Total(result))
+		}
+		progress.Addf(ctx, len(items), "Fetched %d %ss", len(assets), kind)
+		start += len(items)
+		if len(items) == 0 || start >= Total(result) {
+			return assets, nil
+		}
+	}
+}
+
+// DownloadAsset fetches the file of a background or font from its
+// download URI.
+func (c *Client) DownloadAsset(ctx context.Context, a models.Asset) ([]byte, error) {
+	uri := a.DownloadURI
+	if uri == "" {
+		uri = a.Permalink
+	}
+	if uri == "" {
+		return nil, fmt.Errorf("no download URI for %s", a.Id)
+	}
+	if strings.HasPrefix(uri, "/") {
+		uri = c.cfg.BaseURL + uri
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.cfg.BearerToken != "" && underBase(uri, c.cfg.BaseURL) {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.cfg.BearerToken))
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// underBase reports whether uri points into the API at base: same scheme
+// and host, and a path at or below the base path. The token is only sent
+// there, never to lookalike hosts or URIs carrying their own credentials.
+func underBase(uri, base string) bool {
+	u, err := url.Parse(uri)
+	if err != nil || u.User != nil {
+		return false
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, b.Scheme) || !strings.EqualFold(u.Host, b.Host) {
+		return false
+	}
+	prefix := strings.TrimSuffix(b.Path, "/")
+	return u.Path == prefix || strings.HasPrefix(u.Path, prefix+"/")
+}
+
+// UploadAsset adds a background image or font file to the private
+// collection and returns its id.
+func (c *Client) UploadAsset(ctx context.Context, kind, filename string, data []byte, tags []string) (string, error) {
+	field := "image"
+	if kind == AssetFont {
+		field = "font"
+	}
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile(field, filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", err
+	}
+	if len(tags) > 0 {
+		if err := w.WriteField("tags", strings.Join(tags, ",")); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+"/quote/image/"+kind, &buf)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.cfg.BearerToken))
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", newAPIError(resp.StatusCode, body)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	id := ID(result)
+	if id == "" {
+		return "", fmt.Errorf("%s uploaded but no id found in response", kind)
+	}
+	return id, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// QODFilters are the filters of a private QOD definition.
+type QODFilters struct {
+	Title       string
+	RepeatAfter int
+	Authors     []string
+	Language    string
+	SFW         bool
+	Private     bool
+}
+
+// CreateQOD creates a private QOD definition with PUT /qod and returns its
+// id, or "" when the response carries none.
+func (c *Client) CreateQOD(ctx context.Context, f QODFilters) (string, error) {
+	params := url.Values{
+		"title":   {f.Title},
+		"sfw":     {strconv.FormatBool(f.SFW)},
+		"private": {strconv.FormatBool(f.Private)},
+	}
+	if f.RepeatAfter > 0 {
+		params.Set("repeat_after", strconv.Itoa(f.RepeatAfter))
+	}
+	if len(f.Authors) > 0 {
+		params.Set("authors", strings.Join(f.Authors, ","))
+	}
+	if f.Language != "" {
+		params.Set("language", f.Language)
+	}
+	result, err := c.Put(ctx, "/qod", params)
+	if err != nil {
+		return "", err
+	}
+	return ID(result), nil
+}
+
+// QODTTL is how long a quote of the day is reused before it is fetched
+// again. It only changes once a day.
+const QODTTL = 15 * time.Minute
+
+// QOD returns the quote of the day of a public category and language, or
+// of the private definition id when id is set.
+func (c *Client) QOD(ctx context.Context, category, language, id string) (models.QOD, error) {
+	if v, ok := c.cache.Get(c.qodKey(category, language, id)); ok {
+		logging.Debugf(ctx, logging.LoggerCache, "Reusing cached QOD %s", qodParams(category, language, id).Encode())
+		return v.(models.QOD), nil
+	}
+	return c.RefreshQOD(ctx, category, language, id)
+}
+
+// RefreshQOD fetches the quote of the day like QOD, bypassing and
+// replacing the cached one.
+func (c *Client) RefreshQOD(ctx context.Context, category, language, id string) (models.QOD, error) {
+	result, err := c.Get(ctx, "/qod", qodParams(category, language, id))
+	if err != nil {
+		return models.QOD{}, err
+	}
+	qods := QODs(result)
+	if len(qods) == 0 {
+		return models.QOD{}, fmt.Errorf("no quote of the day in the response")
+	}
+	c.cache.Set(c.qodKey(category, language, id), qods[0], QODTTL)
+	return qods[0], nil
+}
+
+// QODs returns the quotes of the day found under contents.quotes.
+func QODs(result map[string]interface{}) []models.QOD {
+	var list models.QODList
+	models.FromMap(Contents(result), &list)
+	if list.Quotes == nil {
+		return []models.QOD{}
+	}
+	return list.Quotes
+}
+
+func qodParams(category, language, id string) url.Values {
+	params := url.Values{}
+	if id != "" {
+		params.Set("id", id)
+	} else if category != "" {
+		params.Set("category", category)
+	}
+	if language != "" {
+		params.Set("language", language)
+	}
+	return params
+}
+
+func (c *Client) qodKey(category, language, id string) string {
+	return c.cacheKey("qod", qodParams(category, language, id).Encode())
+}
+
+// CategoriesTTL is how long category listings are reused.
+const CategoriesTTL = time.Hour
+
+// QODCategories returns the public QOD categories of a language, mapped
+// to their titles.
+func (c *Client) QODCategories(ctx context.Context, language string) (map[string]string, error) {
+	params := url.Values{}
+	if language != "" {
+		params.Set("language", language)
+	}
+	key := c.cacheKey("qod-categories", language)
+	if v, ok := c.cache.Get(key); ok {
+		logging.Debugf(ctx, logging.LoggerCache, "Reusing cached QOD categories of %q", language)
+		return v.(map[string]string), nil
+	}
+	result, err := c.Get(ctx, "/qod/categories", params)
+	if err != nil {
+		return nil, err
+	}
+	categories := map[string]string{}
+	for _, category := range Categories(result) {
+		categories[category.Name] = category.Title
+	}
+	c.cache.Set(key, categories, CategoriesTTL)
+	return categories, nil
+}
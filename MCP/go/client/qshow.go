@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+)
+
+func qshowFromMap(m map[string]interface{}) models.Qshow {
+	var qshow models.Qshow
+	models.FromMap(m, &qshow)
+	return qshow
+}
+
+// CreateQshow creates a private qshow and returns its id.
+func (c *Client) CreateQshow(ctx context.Context, title, description string, tags []string) (string, error) {
+	params := url.Values{"title": {title}}
+	if description != "" {
+		params.Set("description", description)
+	}
+	for _, tag := range tags {
+		params.Add("tags", tag)
+	}
+	result, err := c.Put(ctx, "/qshow", params)
+	if err != nil {
+		return "", err
+	}
+	id := ID(result)
+	if id == "" {
+		return "", fmt.Errorf("qshow created but no id found in response")
+	}
+	return id, nil
+}
+
+// DeleteQshow deletes a qshow.
+func (c *Client) DeleteQshow(ctx context.Context, id string) error {
+	_, err := c.DoRaw(ctx, http.MethodDelete, "/qshow", url.Values{"id": {id}})
+	return err
+}
+
+// AddQshowQuote adds a quote to a qshow.
+func (c *Client) AddQshowQuote(ctx context.Context, qshowID, quoteID string) error {
+	_, err := c.DoRaw(ctx, http.MethodPost, "/qshow/quotes/add", url.Values{"id": {qshowID}, "quoteid": {quoteID}})
+	return err
+}
+
+// RemoveQshowQuote removes a quote from a qshow.
+func (c *Client) RemoveQshowQuote(ctx context.Context, qshowID, quoteID string) error {
+	_, err := c.DoRaw(ctx, http.MethodPost, "/qshow/quotes/remove", url.Values{"id": {qshowID}, "quoteid": {quoteID}})
+	return err
+}
+
+// QshowQuotes returns a qshow and its quotes in order.
+func (c *Client) QshowQuotes(ctx context.Context, id string) (models.Qshow, []models.Quote, error) {
+	result, err := c.Get(ctx, "/qshow/quotes", url.Values{"id": {id}})
+	if err != nil {
+		return models.Qshow{}, nil, err
+	}
+	return QshowFromResult(result), Quotes(result), nil
+}
+
+// QshowFromResult returns the qshow found under contents.qshow.
+func QshowFromResult(result map[string]interface{}) models.Qshow {
+	qshow, _ := Contents(result)["qshow"].(map[string]interface{})
+	return qshowFromMap(qshow)
+}
+
+// Qshows returns the qshows found under contents.qshows.
+func Qshows(result map[string]interface{}) []models.Qshow {
+	var list models.QshowList
+	models.FromMap(Contents(result), &list)
+	if list.Qshows == nil {
+		return []models.Qshow{}
+	}
+	return list.Qshows
+}
+
+// ListQshows walks every page of /qshow/list. When public is set, public
+// qshows are included alongside the private collection.
+func (c *Client) ListQshows(ctx context.Context, public bool) ([]models.Qshow, error) {
+	var qshows []models.Qshow
+	for start := 0; ; {
+		params := url.Values{"start": {strconv.Itoa(start)}}
+		if public {
+			params.Set("public", "true")
+		}
+		result, err := c.Get(ctx, "/qshow/list", params)
+		if err != nil {
+			return qshows, err
+		}
+		page := Qshows(result)
+		qshows = append(qshows, page...)
+		if start == 0 {
+			progress.Expect(ctx, Total(result))
+		}
+		progress.Addf(ctx, len(page), "Fetched %d qshows", len(qshows))
+		start += len(page)
+		if len(page) == 0 || start >= Total(result) {
+			return qshows, nil
+		}
+	}
+}
+
+// PatchQshow updates the given fields of a qshow. Tags are sent as
+// repeated parameters, as for CreateQshow.
+func (c *Client) PatchQshow(ctx context.Context, id string, fields url.Values) error {
+	params := url.Values{"id": {id}}
+	for k, v := range fields {
+		params[k] = v
+	}
+	_, err := c.Patch(ctx, "/qshow", params)
+	return err
+}
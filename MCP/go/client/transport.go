@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+)
+
+// HTTPClient is the HTTP client of every upstream call, from the tools and
+// from Client alike. It logs each call to the session that made it.
+var HTTPClient = &http.Client{Transport: &Transport{Base: http.DefaultTransport}}
+
+// Transport logs upstream calls. Requests must carry the context of the
+// tool call they are made for, or nothing is sent to the client.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok {
+		ctx = logging.WithSecrets(ctx, token)
+	}
+	call := req.Method + " " + req.URL.RequestURI()
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err == nil {
+		logResponse(ctx, call, resp, elapsed)
+	} else if ctx.Err() == nil {
+		logging.Errorf(ctx, logging.LoggerUpstream, "%s failed after %s: %v", call, elapsed, err)
+	}
+	return resp, err
+}
+
+func logResponse(ctx context.Context, call string, resp *http.Response, elapsed time.Duration) {
+	switch {
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		logging.Warningf(ctx, logging.LoggerUpstream, "%s: %s in %s", call, resp.Status, elapsed)
+	case resp.StatusCode >= 400:
+		// Search endpoints answer 404 when nothing matches, so client
+		// errors are only informational here; tools report real failures.
+		logging.Infof(ctx, logging.LoggerUpstream, "%s: %s in %s", call, resp.Status, elapsed)
+	default:
+		logging.Debugf(ctx, logging.LoggerUpstream, "%s: %s in %s", call, resp.Status, elapsed)
+	}
+}
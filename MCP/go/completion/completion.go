@@ -0,0 +1,94 @@
+package completion
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// Completions are offered for these prompt and resource template
+// arguments. Agents otherwise guess values such as categories and font ids.
+var (
+	promptSources = map[string]map[string]Source{
+		"daily_inspiration": {"category": matching(qodCategories), "language": matching(languages)},
+		"build_qshow":       {"topic": matching(quoteCategories), "language": matching(languages)},
+		"brand_quote_card": {
+			"topic":      matching(quoteCategories),
+			"size":       matching(sizes),
+			"bgimage_id": assets(client.AssetBackground),
+			"font_id":    assets(client.AssetFont),
+		},
+		"curate_author": {"author": matching(authors), "language": matching(languages)},
+	}
+	resourceSources = map[string]map[string]Source{
+		"qod://{category}/{language}": {"category": matching(qodCategories), "language": matching(languages)},
+		"qod://id/{id}":               {"id": qodDefinitions},
+		"author://{name}":             {"name": matching(authors)},
+	}
+)
+
+// Exposed returns, for the request of ctx, the configuration to fetch
+// values with and the prompts and resource templates its session is shown.
+// A nil configuration completes nothing.
+type Exposed func(ctx context.Context) (*config.APIConfig, []models.Prompt, []models.ResourceTemplate)
+
+// Provider completes prompt and resource template arguments. It
+// implements the server's PromptCompletionProvider and
+// ResourceCompletionProvider. Only the prompts and templates the session
+// is shown are completed, so completions do not list what their toolset
+// hides.
+type Provider struct {
+	exposed Exposed
+}
+
+func NewProvider(exposed Exposed) *Provider {
+	return &Provider{exposed: exposed}
+}
+
+func (p *Provider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, context mcp.CompleteContext) (*mcp.Completion, error) {
+	cfg, prompts, _ := p.exposed(ctx)
+	var source Source
+	for _, prompt := range prompts {
+		if prompt.Definition.Name == promptName {
+			source = promptSources[promptName][argument.Name]
+		}
+	}
+	return complete(ctx, cfg, source, argument.Value, context.Arguments), nil
+}
+
+func (p *Provider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, context mcp.CompleteContext) (*mcp.Completion, error) {
+	cfg, _, templates := p.exposed(ctx)
+	var source Source
+	for _, template := range templates {
+		if template.Definition.URITemplate.Raw() == uri {
+			source = resourceSources[uri][argument.Name]
+		}
+	}
+	return complete(ctx, cfg, source, argument.Value, context.Arguments), nil
+}
+
+// complete matches the values of source against partial. An upstream
+// error gives no completions rather than failing the request, since the
+// user can still type the value.
+func complete(ctx context.Context, cfg *config.APIConfig, source Source, partial string, args map[string]string) *mcp.Completion {
+	completion := &mcp.Completion{Values: []string{}}
+	if source == nil || cfg == nil {
+		return completion
+	}
+	if args == nil {
+		args = map[string]string{}
+	}
+	values, err := source(ctx, client.New(cfg), partial, args)
+	if err != nil {
+		return completion
+	}
+	completion.Total = len(values)
+	if len(values) > MaxValues {
+		values, completion.HasMore = values[:MaxValues], true
+	}
+	completion.Values = values
+	return completion
+}
@@ -0,0 +1,81 @@
+package completion
+
+import (
+	"sort"
+	"strings"
+)
+
+// MaxValues is the most values one completion may return.
+const MaxValues = 100
+
+// Match ranks the candidates that match partial: prefix matches first,
+// then matches at the start of a later word, then anywhere in the value,
+// then fuzzy matches where the letters of partial appear in order.
+// Matching ignores case, and duplicates are dropped. Every candidate
+// matches an empty partial.
+func Match(candidates []string, partial string) []string {
+	want := strings.ToLower(strings.TrimSpace(partial))
+	type ranked struct {
+		value string
+		rank  int
+	}
+	seen := map[string]bool{}
+	var matches []ranked
+	for _, c := range candidates {
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		if rank, ok := matchRank(strings.ToLower(c), want); ok {
+			matches = append(matches, ranked{c, rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank < matches[j].rank
+	})
+	values := make([]string, len(matches))
+	for i, m := range matches {
+		values[i] = m.value
+	}
+	return values
+}
+
+func matchRank(value, want string) (int, bool) {
+	switch {
+	case strings.HasPrefix(value, want):
+		return 0, true
+	case wordPrefix(value, want):
+		return 1, true
+	case strings.Contains(value, want):
+		return 2, true
+	case subsequence(value, want):
+		return 3, true
+	}
+	return 0, false
+}
+
+// wordPrefix reports whether a word after the first starts with want, so
+// "twain" finds "Mark Twain" and "inspired" finds "self-inspired".
+func wordPrefix(value, want string) bool {
+	for i := 1; i < len(value); i++ {
+		if strings.ContainsRune(" -_", rune(value[i-1])) && strings.HasPrefix(value[i:], want) {
+			return true
+		}
+	}
+	return false
+}
+
+// subsequence reports whether the letters of want appear in value in
+// order, e.g. "mtwn" in "mark twain".
+func subsequence(value, want string) bool {
+	rest := []rune(want)
+	for _, r := range value {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
@@ -0,0 +1,71 @@
+package completion
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		partial    string
+		want       []string
+	}{
+		{
+			name:       "empty partial matches everything",
+			candidates: []string{"b", "a"},
+			partial:    "",
+			want:       []string{"b", "a"},
+		},
+		{
+			name:       "prefix before word prefix before substring",
+			candidates: []string{"Mark Twain", "twain", "Stwain"},
+			partial:    "twain",
+			want:       []string{"twain", "Mark Twain", "Stwain"},
+		},
+		{
+			name:       "words split on hyphens and underscores",
+			candidates: []string{"self-inspired", "inspired_by", "uninspired"},
+			partial:    "insp",
+			want:       []string{"inspired_by", "self-inspired", "uninspired"},
+		},
+		{
+			name:       "fuzzy matches come last",
+			candidates: []string{"mark twain", "mtwn"},
+			partial:    "mtwn",
+			want:       []string{"mtwn", "mark twain"},
+		},
+		{
+			name:       "case and surrounding blanks are ignored",
+			candidates: []string{"Inspire"},
+			partial:    "  INS ",
+			want:       []string{"Inspire"},
+		},
+		{
+			name:       "duplicates and empty values are dropped",
+			candidates: []string{"love", "", "love", "Love"},
+			partial:    "lo",
+			want:       []string{"love", "Love"},
+		},
+		{
+			name:       "letters out of order do not match",
+			candidates: []string{"twain"},
+			partial:    "niawt",
+			want:       []string{},
+		},
+		{
+			name:       "ties keep the order of the candidates",
+			candidates: []string{"life", "love", "laughter"},
+			partial:    "l",
+			want:       []string{"life", "love", "laughter"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.candidates, tt.partial); !slices.Equal(got, tt.want) {
+				t.Errorf("Match(%q, %q) = %q, want %q", tt.candidates, tt.partial, got, tt.want)
+			}
+		})
+	}
+}
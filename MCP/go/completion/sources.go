@@ -0,0 +1,155 @@
+package completion
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// ListTTL is how long the values behind completions are reused.
+const ListTTL = 10 * time.Minute
+
+// searchPrefixLength is how much of a partial value is sent to the search
+// endpoints. The results are cached per prefix and matched locally, so
+// typing further, or a typo after the prefix, costs no upstream call.
+const searchPrefixLength = 3
+
+// searchLimit is how many results a search for a prefix asks for.
+const searchLimit = 100
+
+// A Source returns the values of an argument that match partial, best
+// first. args holds the arguments the client has already resolved, e.g.
+// the language when completing a QOD category.
+type Source func(ctx context.Context, c *client.Client, partial string, args map[string]string) ([]string, error)
+
+// matching makes a Source from a function listing every candidate.
+func matching(list Source) Source {
+	return func(ctx context.Context, c *client.Client, partial string, args map[string]string) ([]string, error) {
+		candidates, err := list(ctx, c, partial, args)
+		if err != nil {
+			return nil, err
+		}
+		return Match(candidates, partial), nil
+	}
+}
+
+func searchPrefix(partial string) string {
+	runes := []rune(strings.ToLower(strings.TrimSpace(partial)))
+	if len(runes) > searchPrefixLength {
+		runes = runes[:searchPrefixLength]
+	}
+	return string(runes)
+}
+
+func cachedStrings(ctx context.Context, c *client.Client, key string, fetch func() ([]string, error)) ([]string, error) {
+	v, err := c.Cached(ctx, "completion:"+key, ListTTL, func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]string), nil
+}
+
+func qodCategories(ctx context.Context, c *client.Client, _ string, args map[string]string) ([]string, error) {
+	categories, err := c.QODCategories(ctx, args["language"])
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func quoteCategories(ctx context.Context, c *client.Client, partial string, _ map[string]string) ([]string, error) {
+	prefix := searchPrefix(partial)
+	if prefix == "" {
+		return nil, nil
+	}
+	return cachedStrings(ctx, c, "categories:"+prefix, func() ([]string, error) {
+		return c.SearchCategories(ctx, prefix, searchLimit)
+	})
+}
+
+func languages(ctx context.Context, c *client.Client, _ string, _ map[string]string) ([]string, error) {
+	return cachedStrings(ctx, c, "languages", func() ([]string, error) {
+		return c.Languages(ctx)
+	})
+}
+
+func authors(ctx context.Context, c *client.Client, partial string, args map[string]string) ([]string, error) {
+	prefix := searchPrefix(partial)
+	if prefix == "" {
+		return nil, nil
+	}
+	language := args["language"]
+	return cachedStrings(ctx, c, "authors:"+language+":"+prefix, func() ([]string, error) {
+		found, err := c.SearchAuthors(ctx, prefix, language, false, searchLimit)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(found))
+		for _, a := range found {
+			names = append(names, a.Name)
+		}
+		return names, nil
+	})
+}
+
+// qodDefinitions lists the ids of the private QOD definitions known to
+// the local registry, with their titles so either can be typed.
+func qodDefinitions(_ context.Context, c *client.Client, partial string, _ map[string]string) ([]string, error) {
+	defs, err := qodstore.Open(c.Config()).List(c.Account())
+	if err != nil {
+		return nil, err
+	}
+	var ids, byTitle []string
+	for _, d := range defs {
+		if d.ID == "" {
+			continue
+		}
+		ids = append(ids, d.ID)
+		if len(Match([]string{d.Title}, partial)) > 0 {
+			byTitle = append(byTitle, d.ID)
+		}
+	}
+	return Match(append(Match(ids, partial), byTitle...), ""), nil
+}
+
+// assets completes the id of a background or font. The partial value is
+// also matched against names and tags, so "navy" finds the id of a
+// background tagged navy.
+func assets(kind string) Source {
+	return func(ctx context.Context, c *client.Client, partial string, _ map[string]string) ([]string, error) {
+		v, err := c.Cached(ctx, "completion:assets:"+kind, ListTTL, func() (interface{}, error) {
+			return c.ListAssets(ctx, kind)
+		})
+		if err != nil {
+			return nil, err
+		}
+		var ids, byLabel []string
+		for _, a := range v.([]models.Asset) {
+			ids = append(ids, a.Id)
+			for _, label := range append([]string{a.Name}, a.Tags...) {
+				if len(Match([]string{label}, partial)) > 0 {
+					byLabel = append(byLabel, a.Id)
+					break
+				}
+			}
+		}
+		return Match(append(Match(ids, partial), byLabel...), ""), nil
+	}
+}
+
+// sizes are the presets of create_quote_card.
+func sizes(context.Context, *client.Client, string, map[string]string) ([]string, error) {
+	return []string{"square", "portrait", "landscape", "story"}, nil
+}
@@ -1,16 +1,47 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
+	"github.com/they-said-so-quotes-api/mcp-server/toolsets"
+)
+
+// Policies accepted by CONFIRM_DESTRUCTIVE.
+const (
+	// ConfirmAlways confirms every destructive call.
+	ConfirmAlways = "always"
+	// ConfirmBulk confirms calls that change many items at once, such as
+	// the batch tools and pushing a sync (the default).
+	ConfirmBulk = "bulk"
+	// ConfirmNever confirms nothing.
+	ConfirmNever = "never"
 )
 
+var ConfirmPolicies = []string{ConfirmAlways, ConfirmBulk, ConfirmNever}
+
 type APIConfig struct {
 	BaseURL     string
 	BearerToken string // For OAuth2/Bearer authentication
 	APIKey      string // For API key authentication
 	BasicAuth   string // For basic authentication
 	Port        string // For server port configuration
+	DataDir     string // For local state such as the QOD definition registry
+	FileRoot    string // Directory the files tools read and write are confined to; empty refuses them
+	OtherHosts  bool   // Tools may call API base URLs other than BaseURL, as CLI commands do
+
+	QODPollInterval time.Duration   // How often subscribed QOD resources are checked; 0 uses the default
+	ConfirmPolicy   string          // When destructive tools ask the user to confirm: always, bulk or never
+	Toolsets        []string        // Toolsets whose tools are exposed; nil exposes all of them
+	ReadOnly        bool            // Hide every tool that changes data
+	Capabilities    map[string]bool // Capabilities forced on or off instead of probed
 }
 
 func LoadAPIConfig() (*APIConfig, error) {
@@ -36,13 +67,102 @@ func LoadAPIConfig() (*APIConfig, error) {
 	// For HTTP/HTTPS mode (transport is "http"/"HTTP"/"https"/"HTTPS"), API_BASE_URL comes from headers
 	// so we don't require it from environment variables
 
+	var pollInterval time.Duration
+	if v := os.Getenv("QOD_POLL_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid QOD_POLL_INTERVAL %q: expected a positive duration such as 5m", v)
+		}
+		pollInterval = d
+	}
+
+	confirmPolicy := strings.ToLower(os.Getenv("CONFIRM_DESTRUCTIVE"))
+	if confirmPolicy == "" {
+		confirmPolicy = ConfirmBulk
+	}
+	if !slices.Contains(ConfirmPolicies, confirmPolicy) {
+		return nil, fmt.Errorf("invalid CONFIRM_DESTRUCTIVE %q: expected %s", confirmPolicy, strings.Join(ConfirmPolicies, ", "))
+	}
+
+	enabled, err := toolsets.Parse(os.Getenv("TOOLSETS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOOLSETS: %w", err)
+	}
+
+	readOnly, err := ParseBool(os.Getenv("READ_ONLY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid READ_ONLY %q: expected true or false", os.Getenv("READ_ONLY"))
+	}
+
+	forced, err := capabilities.Parse(os.Getenv("CAPABILITIES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CAPABILITIES: %w", err)
+	}
+
+	dataDir := os.Getenv("DATA_DIR")
+	// Only a local client may reach files, and only those in the data
+	// directory; in HTTP mode every request gets a config without FileRoot
+	fileRoot := dataDir
+	if fileRoot == "" {
+		fileRoot = DefaultDataDir()
+	}
+
 	return &APIConfig{
 		BaseURL:     baseURL,
 		BearerToken: os.Getenv("BEARER_TOKEN"),
 		APIKey:      os.Getenv("API_KEY"),
 		BasicAuth:   os.Getenv("BASIC_AUTH"),
 		Port:        port,
+		DataDir:     dataDir,
+		FileRoot:    fileRoot,
+
+		QODPollInterval: pollInterval,
+		ConfirmPolicy:   confirmPolicy,
+		Toolsets:        enabled,
+		ReadOnly:        readOnly,
+		Capabilities:    forced,
 	}, nil
 }
 
+// ParseBool reads a boolean setting, where an empty value is false.
+func ParseBool(v string) (bool, error) {
+	if strings.TrimSpace(v) == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(strings.TrimSpace(v))
+}
+
+// DefaultDataDir is the data directory used when DATA_DIR is not set.
+func DefaultDataDir() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "they-said-so-mcp")
+	}
+	return "."
+}
+
+// LocalPath resolves a path given to a tool under FileRoot. The path must
+// be relative and stay inside FileRoot, so callers cannot reach other
+// files of the server.
+func (cfg *APIConfig) LocalPath(path string) (string, error) {
+	if cfg.FileRoot == "" {
+		return "", fmt.Errorf("files cannot be read or written through this server")
+	}
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("%q must be a relative path inside %s", path, cfg.FileRoot)
+	}
+	return filepath.Join(cfg.FileRoot, path), nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying cfg as the configuration of
+// the request ctx is for. In HTTP mode it comes from the request headers.
+func NewContext(ctx context.Context, cfg *APIConfig) context.Context {
+	return context.WithValue(ctx, contextKey{}, cfg)
+}
 
+// FromContext returns the configuration NewContext recorded in ctx, or nil.
+func FromContext(ctx context.Context) *APIConfig {
+	cfg, _ := ctx.Value(contextKey{}).(*APIConfig)
+	return cfg
+}
@@ -0,0 +1,162 @@
+// Package confirm asks the human behind the client to confirm destructive
+// tool calls, through MCP elicitation, before they change curated data.
+//
+// Whether a call is confirmed depends on the server's policy and on the
+// client: under the bulk policy calls go ahead unasked when the client does
+// not support elicitation, as it then relies on the tools' destructive
+// annotations, while under the always policy they are refused.
+package confirm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+)
+
+// MaxListed is how many items of a bulk change are listed in the message;
+// the rest are counted.
+const MaxListed = 10
+
+// Change describes what a destructive call is about to do: a one-line
+// summary and the lines detailing it.
+type Change struct {
+	Summary string
+	Details []string
+}
+
+// required reports whether policy asks to confirm a change.
+func required(policy string, bulk bool) bool {
+	switch policy {
+	case config.ConfirmNever:
+		return false
+	case config.ConfirmAlways:
+		return true
+	}
+	return bulk
+}
+
+// supported reports whether the client of ctx declared elicitation.
+func supported(ctx context.Context) bool {
+	if server.ServerFromContext(ctx) == nil {
+		return false
+	}
+	cs, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return false
+	}
+	if _, ok := cs.(server.SessionWithElicitation); !ok {
+		return false
+	}
+	return cs.GetClientCapabilities().Elicitation != nil
+}
+
+// Ask confirms a destructive call when policy requires it for a change
+// that is bulk or not, and the client can ask. describe is only called
+// then, so it may fetch what the message shows. A non-nil result means the
+// call was not confirmed and the handler should return it instead of
+// going on.
+//
+// Under the always policy a client that cannot ask is refused, except for
+// CLI commands, which have no server and are run by the user themselves.
+func Ask(ctx context.Context, policy string, bulk bool, describe func() (Change, error)) *mcp.CallToolResult {
+	if !required(policy, bulk) {
+		return nil
+	}
+	canAsk := supported(ctx)
+	if !canAsk && (policy != config.ConfirmAlways || server.ServerFromContext(ctx) == nil) {
+		return nil
+	}
+	change, err := describe()
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to prepare the confirmation", err)
+	}
+	if change.Summary == "" {
+		// Nothing would change.
+		return nil
+	}
+	if !canAsk {
+		return mcp.NewToolResultError(fmt.Sprintf("CONFIRM_DESTRUCTIVE is always but this client cannot be asked to confirm, as it does not support elicitation, so nothing was changed: %s", change.Summary))
+	}
+
+	result, err := server.ServerFromContext(ctx).RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: Message(change),
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"confirm": map[string]any{
+						"type":        "boolean",
+						"title":       "Apply this change",
+						"description": "Check to go ahead. This cannot be undone from here.",
+					},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if err != nil {
+		logging.Warningf(ctx, logging.LoggerTool, "Could not ask to confirm %q: %v", change.Summary, err)
+		return mcp.NewToolResultErrorFromErr("Could not ask the user to confirm the change, so nothing was changed", err)
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept || !accepted(result.Content) {
+		logging.Infof(ctx, logging.LoggerTool, "The user did not confirm %q", change.Summary)
+		return mcp.NewToolResultError(fmt.Sprintf("Not confirmed by the user, nothing was changed: %s", change.Summary))
+	}
+	return nil
+}
+
+// accepted reads the confirm field of an accepted answer. Clients that
+// accept without content are taken at their word.
+func accepted(content any) bool {
+	m, ok := content.(map[string]any)
+	if !ok {
+		return true
+	}
+	v, ok := m["confirm"]
+	if !ok {
+		return true
+	}
+	b, ok := v.(bool)
+	return ok && b
+}
+
+// Message renders a change for the user.
+func Message(change Change) string {
+	var b strings.Builder
+	b.WriteString(change.Summary)
+	for _, line := range change.Details {
+		b.WriteString("\n- ")
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// List renders items for Details, listing at most MaxListed of them.
+func List(items []string) []string {
+	if len(items) <= MaxListed {
+		return items
+	}
+	out := append([]string{}, items[:MaxListed]...)
+	return append(out, fmt.Sprintf("and %d more", len(items)-MaxListed))
+}
+
+// Items confirms a change applied to each of ids, which is bulk when there
+// are several. The ids are listed after details.
+func Items(ctx context.Context, policy, summary string, ids []string, details ...string) *mcp.CallToolResult {
+	return Ask(ctx, policy, len(ids) > 1, func() (Change, error) {
+		return Change{Summary: summary, Details: append(details, List(ids)...)}, nil
+	})
+}
+
+// Field renders the change of a field for Details.
+func Field(name, old, new string) string {
+	if old == "" {
+		return fmt.Sprintf("%s: set to %q", name, new)
+	}
+	return fmt.Sprintf("%s: %q → %q", name, old, new)
+}
@@ -0,0 +1,115 @@
+package dedupe
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// Policies accepted by the `on_duplicate` argument of put_quote and
+// post_quote.
+const (
+	PolicyAllow          = "allow"
+	PolicyReject         = "reject"
+	PolicyWarn           = "warn"
+	PolicyReturnExisting = "return_existing"
+)
+
+var Policies = []string{PolicyAllow, PolicyReject, PolicyWarn, PolicyReturnExisting}
+
+// Normalize reduces a quote to the form used to compare quotes: lower
+// case, every punctuation mark or symbol (straight and curly quotes, dashes,
+// ellipses...) replaced by a space, and runs of whitespace collapsed.
+func Normalize(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			r = ' '
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// searchQuery picks the leading words of a quote as a keyword for
+// /quote/search; the full text is too specific for the upstream matcher.
+func searchQuery(normalized string) string {
+	words := strings.Fields(normalized)
+	if len(words) > 6 {
+		words = words[:6]
+	}
+	return strings.Join(words, " ")
+}
+
+// FindExisting looks for a quote in the private collection whose text
+// normalizes to the same string as text. It asks /quote/search first and
+// falls back to the cached listing of the whole collection.
+func FindExisting(ctx context.Context, c *client.Client, text string) (*models.Quote, error) {
+	want := Normalize(text)
+	if want == "" {
+		return nil, nil
+	}
+	params := url.Values{"query": {searchQuery(want)}, "private": {"true"}, "limit": {"10"}}
+	if quotes, err := c.SearchQuotes(ctx, params); err == nil {
+		for _, q := range quotes {
+			if Normalize(q.Quote) == want {
+				return &q, nil
+			}
+		}
+	}
+	quotes, err := c.CachedPrivateQuotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range quotes {
+		if Normalize(q.Quote) == want {
+			return &q, nil
+		}
+	}
+	return nil, nil
+}
+
+// Cluster is a group of quotes that normalize to the same text.
+type Cluster struct {
+	Normalized string         `json:"normalized"`
+	Quotes     []models.Quote `json:"quotes"`
+}
+
+// Clusters groups quotes by normalized text (and author when byAuthor is
+// set) and returns the groups with more than one member, largest first.
+func Clusters(quotes []models.Quote, byAuthor bool) []Cluster {
+	groups := map[string]*Cluster{}
+	var order []string
+	for _, q := range quotes {
+		norm := Normalize(q.Quote)
+		if norm == "" {
+			continue
+		}
+		key := norm
+		if byAuthor {
+			key += "\x00" + Normalize(q.Author)
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &Cluster{Normalized: norm}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.Quotes = append(g.Quotes, q)
+	}
+	clusters := []Cluster{}
+	for _, key := range order {
+		if g := groups[key]; len(g.Quotes) > 1 {
+			clusters = append(clusters, *g)
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Quotes) > len(clusters[j].Quotes)
+	})
+	return clusters
+}
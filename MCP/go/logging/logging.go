@@ -0,0 +1,121 @@
+// Package logging sends log messages to the MCP client whose request they
+// come from, as notifications/message, at the level the client chose with
+// logging/setLevel.
+//
+// Messages are scoped to the session in the context they are logged with,
+// so one client never sees the upstream calls of another. Secrets are
+// redacted from every message before it leaves the server.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultLevel is the least severe level sent to a session that has not
+// set one: failures and errors, but not every upstream call.
+const DefaultLevel = mcp.LoggingLevelWarning
+
+// StderrLevel is the least severe level also written to the server's own
+// log.
+const StderrLevel = mcp.LoggingLevelWarning
+
+// Loggers name the part of the server a message comes from.
+const (
+	LoggerUpstream = "upstream"
+	LoggerCache    = "cache"
+	LoggerTool     = "tool"
+)
+
+// Attach starts the sessions of a server at DefaultLevel rather than the
+// server's own default, until they set one with logging/setLevel. hooks
+// must be the hooks the server was created with.
+func Attach(hooks *server.Hooks) {
+	hooks.AddAfterInitialize(func(ctx context.Context, id any, message *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		if cs, ok := server.ClientSessionFromContext(ctx).(server.SessionWithLogging); ok {
+			cs.SetLogLevel(DefaultLevel)
+		}
+	})
+}
+
+// Level returns the level set by the session of ctx.
+func Level(ctx context.Context) mcp.LoggingLevel {
+	if cs, ok := server.ClientSessionFromContext(ctx).(server.SessionWithLogging); ok {
+		return cs.GetLogLevel()
+	}
+	return DefaultLevel
+}
+
+// Enabled reports whether a message at level would be sent to the session
+// of ctx, so callers can skip building expensive messages.
+func Enabled(ctx context.Context, level mcp.LoggingLevel) bool {
+	return level.ShouldSendTo(Level(ctx))
+}
+
+// Logf sends a message to the session of ctx when its level allows, and
+// writes it to the server's log when it is at least StderrLevel. Without a
+// session in ctx, e.g. on the command line, only the latter applies.
+func Logf(ctx context.Context, level mcp.LoggingLevel, logger, format string, args ...interface{}) {
+	message := Redact(ctx, fmt.Sprintf(format, args...))
+	if level.ShouldSendTo(StderrLevel) {
+		log.Printf("[%s] %s: %s", level, logger, message)
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil || !Enabled(ctx, level) {
+		return
+	}
+	// The client may be gone; the request goes on regardless.
+	_ = srv.SendNotificationToClient(ctx, "notifications/message", map[string]any{
+		"level":  level,
+		"logger": logger,
+		"data":   message,
+	})
+}
+
+func Debugf(ctx context.Context, logger, format string, args ...interface{}) {
+	Logf(ctx, mcp.LoggingLevelDebug, logger, format, args...)
+}
+
+func Infof(ctx context.Context, logger, format string, args ...interface{}) {
+	Logf(ctx, mcp.LoggingLevelInfo, logger, format, args...)
+}
+
+func Warningf(ctx context.Context, logger, format string, args ...interface{}) {
+	Logf(ctx, mcp.LoggingLevelWarning, logger, format, args...)
+}
+
+func Errorf(ctx context.Context, logger, format string, args ...interface{}) {
+	Logf(ctx, mcp.LoggingLevelError, logger, format, args...)
+}
+
+// Middleware records the credentials secrets returns for a tool call in
+// its context, so they are redacted from its messages, and logs the calls
+// that fail.
+func Middleware(secrets func(ctx context.Context) []string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx = WithSecrets(ctx, secrets(ctx)...)
+			result, err := next(ctx, request)
+			switch {
+			case err != nil:
+				Errorf(ctx, LoggerTool, "%s failed: %v", request.Params.Name, err)
+			case result != nil && result.IsError:
+				Errorf(ctx, LoggerTool, "%s failed: %s", request.Params.Name, resultText(result))
+			}
+			return result, err
+		}
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			return text.Text
+		}
+	}
+	return "no details"
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets in log messages.
+const Redacted = "[REDACTED]"
+
+type secretsKey struct{}
+
+// WithSecrets returns ctx with values that Redact removes from messages
+// logged with it, such as the credentials of the account in use. Short
+// values are ignored, as replacing them would mangle ordinary text.
+func WithSecrets(ctx context.Context, secrets ...string) context.Context {
+	var keep []string
+	for _, s := range secrets {
+		if len(s) >= 4 {
+			keep = append(keep, s)
+		}
+	}
+	if len(keep) == 0 {
+		return ctx
+	}
+	prev, _ := ctx.Value(secretsKey{}).([]string)
+	return context.WithValue(ctx, secretsKey{}, append(append([]string{}, prev...), keep...))
+}
+
+var (
+	// authPattern matches credentials following an authorization scheme.
+	authPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+	// paramPattern matches the values of query parameters and JSON or
+	// header fields with sensitive names. An authorization scheme before
+	// the value is kept, so headers read as the scheme and the redaction.
+	paramPattern = regexp.MustCompile(`(?i)\b([a-z_-]*(?:token|api[_-]?key|secret|password|passwd|auth|authorization))(["']?\s*[=:]\s*["']?)((?:bearer|basic)\s+)?[^&\s"',;}]+`)
+	// userinfoPattern matches credentials embedded in URLs.
+	userinfoPattern = regexp.MustCompile(`(://)[^/\s:@]+:[^/\s@]+@`)
+)
+
+// Redact removes the secrets of ctx, authorization credentials, sensitive
+// parameters and URL user info from message.
+func Redact(ctx context.Context, message string) string {
+	if secrets, ok := ctx.Value(secretsKey{}).([]string); ok {
+		for _, s := range secrets {
+			message = strings.ReplaceAll(message, s, Redacted)
+		}
+	}
+	message = authPattern.ReplaceAllString(message, "$1 "+Redacted)
+	message = paramPattern.ReplaceAllString(message, "$1$2$3"+Redacted)
+	return userinfoPattern.ReplaceAllString(message, "$1"+Redacted+"@")
+}
@@ -2,16 +2,27 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/they-said-so-quotes-api/mcp-server/capstore"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/completion"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+	"github.com/they-said-so-quotes-api/mcp-server/subscriptions"
+	"github.com/they-said-so-quotes-api/mcp-server/toolsets"
 )
 
 func main() {
@@ -20,6 +31,11 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// A command argument runs a single tool from the command line instead of starting the server
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(cfg, os.Args[1], os.Args[2:]))
+	}
+
 	// Check transport environment variable (both uppercase and lowercase)
 	transport := os.Getenv("TRANSPORT")
 	if transport == "" {
@@ -45,31 +61,32 @@ func main() {
 		
 		log.Printf("Running in %s mode on port %s", transport, port)
 
+		// One server serves every session. The headers of each request give
+		// its configuration, which decides what its session is shown and
+		// what its calls use
+		mcpSrv := createMCPServer(cfg, transport)
+		handler := server.NewStreamableHTTPServer(mcpSrv,
+			server.WithSessionIdleTTL(sessionIdleTTL),
+			server.WithHTTPContextFunc(
+				func(ctx context.Context, r *http.Request) context.Context {
+					apiCfg, err := headerConfig(cfg, r)
+					if err != nil {
+						// Refused before reaching the server
+						return ctx
+					}
+					return withConfig(ctx, apiCfg)
+				},
+			),
+		)
+
 		mux := http.NewServeMux()
 		mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
-			// Read headers for dynamic config
-			apiCfg := &config.APIConfig{
-				BaseURL:     r.Header.Get("API_BASE_URL"),
-				BearerToken: r.Header.Get("BEARER_TOKEN"),
-				APIKey:      r.Header.Get("API_KEY"),
-				BasicAuth:   r.Header.Get("BASIC_AUTH"),
-			}
-
-			if apiCfg.BaseURL == "" {
-				http.Error(w, "Missing API_BASE_URL header", http.StatusBadRequest)
+			apiCfg, err := headerConfig(cfg, r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-
-			log.Printf("Incoming HTTP request - BaseURL: %s", apiCfg.BaseURL)
-
-			// Create MCP server for this request
-			mcpSrv := createMCPServer(apiCfg, transport)
-			handler := server.NewStreamableHTTPServer(mcpSrv, server.WithHTTPContextFunc(
-				func(ctx context.Context, req *http.Request) context.Context {
-					return context.WithValue(ctx, "apiConfig", apiCfg)
-				},
-			))
-
+			log.Printf("Incoming HTTP request - BaseURL: %s", logging.Redact(r.Context(), apiCfg.BaseURL))
 			handler.ServeHTTP(w, r)
 		})
 
@@ -118,9 +135,12 @@ func main() {
 
 	// STDIO Mode - default when no transport or transport is "stdio"
 	log.Println("Running in STDIO mode")
-	mcp := createMCPServer(cfg, "STDIO")
+	mcpSrv := createMCPServer(cfg, "STDIO")
 	go func() {
-		if err := server.ServeStdio(mcp); err != nil {
+		err := server.ServeStdio(mcpSrv, server.WithStdioContextFunc(func(ctx context.Context) context.Context {
+			return withConfig(ctx, cfg)
+		}))
+		if err != nil {
 			log.Fatalf("STDIO error: %v", err)
 		}
 	}()
@@ -128,18 +148,106 @@ func main() {
 	log.Println("Received shutdown signal. Exiting STDIO mode.")
 }
 
+// sessionIdleTTL is how long an HTTP session is kept after its last
+// request, along with its subscriptions and log level.
+const sessionIdleTTL = time.Hour
+
+// headerConfig reads the configuration of an HTTP request from its
+// headers. Credentials and the API come from them; the rest comes from
+// env, which the headers can only narrow.
+func headerConfig(env *config.APIConfig, r *http.Request) (*config.APIConfig, error) {
+	cfg := &config.APIConfig{
+		BaseURL:     r.Header.Get("API_BASE_URL"),
+		BearerToken: r.Header.Get("BEARER_TOKEN"),
+		APIKey:      r.Header.Get("API_KEY"),
+		BasicAuth:   r.Header.Get("BASIC_AUTH"),
+		DataDir:     env.DataDir,
+
+		ConfirmPolicy: env.ConfirmPolicy,
+		Capabilities:  env.Capabilities,
+	}
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("Missing API_BASE_URL header")
+	}
+
+	// Headers can narrow the tools the environment exposes, never widen
+	// them, so untrusted clients cannot lift the limits
+	enabled, err := toolsets.Narrow(env.Toolsets, r.Header.Get("TOOLSETS"))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid TOOLSETS header: %v", err)
+	}
+	readOnly, err := config.ParseBool(r.Header.Get("READ_ONLY"))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid READ_ONLY header: expected true or false")
+	}
+	cfg.Toolsets = enabled
+	cfg.ReadOnly = env.ReadOnly || readOnly
+	return cfg, nil
+}
+
+// createMCPServer builds the server for every session. The tools, prompts
+// and resource templates cfg allows are registered, and each request is
+// shown and can use those its own configuration, which withConfig records
+// in its context, allows.
 func createMCPServer(cfg *config.APIConfig, mode string) *server.MCPServer {
-	mcp := server.NewMCPServer("They Said So Quotes API", "5.1",
+	store := capstore.NewStore()
+	hub := subscriptions.NewHub()
+	completions := completion.NewProvider(func(ctx context.Context) (*config.APIConfig, []models.Prompt, []models.ResourceTemplate) {
+		v := viewOf(ctx, store)
+		return v.cfg, v.prompts, v.templates
+	})
+	hooks := &server.Hooks{}
+	srv := server.NewMCPServer("They Said So Quotes API", "5.1",
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(true),
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(completions),
+		server.WithResourceCompletionProvider(completions),
+		server.WithHooks(hooks),
 		server.WithRecovery(),
+		server.WithLogging(),
+		server.WithToolFilter(func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+			return viewOf(ctx, store).filterTools(tools)
+		}),
+		server.WithPromptFilter(func(ctx context.Context, prompts []mcp.Prompt) []mcp.Prompt {
+			return viewOf(ctx, store).filterPrompts(prompts)
+		}),
+		server.WithToolHandlerMiddleware(progress.Middleware),
+		server.WithToolHandlerMiddleware(logging.Middleware(func(ctx context.Context) []string {
+			if c := config.FromContext(ctx); c != nil {
+				return []string{c.BearerToken, c.APIKey, c.BasicAuth}
+			}
+			return nil
+		})),
 	)
-
-	tools := GetAll(cfg)
-	log.Printf("Loaded %d tools for %s mode", len(tools), mode)
-
-	for _, tool := range tools {
-		mcp.AddTool(tool.Definition, tool.Handler)
+	// Templates have no filter of their own, so their listing is narrowed
+	// after the fact; reading one outside the view is refused by its
+	// handler
+	hooks.AddAfterListResourceTemplates(func(ctx context.Context, id any, message *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+		result.ResourceTemplates = viewOf(ctx, store).filterTemplates(result.ResourceTemplates)
+	})
+	logging.Attach(hooks)
+	hub.Attach(srv, hooks)
+	// When the capabilities of a session change it is told to list the
+	// tools again, which the filters then show for the new ones
+	store.Attach(srv, hooks, func(ctx context.Context) (string, capstore.Probe) {
+		apiCfg := config.FromContext(ctx)
+		if apiCfg == nil {
+			return "", nil
+		}
+		c := client.New(apiCfg)
+		return c.Account(), c.Capabilities
+	})
+	go hub.Run(context.Background(), cfg.QODPollInterval)
+	go store.Run(context.Background())
+
+	all := newView(cfg, nil)
+	register(srv, store, all)
+	if cfg.ReadOnly {
+		log.Printf("Loaded %d read-only tools for %s mode (toolsets: %s)", len(all.tools), mode, strings.Join(cfg.Toolsets, ", "))
+	} else {
+		log.Printf("Loaded %d tools for %s mode (toolsets: %s)", len(all.tools), mode, strings.Join(cfg.Toolsets, ", "))
 	}
-
-	return mcp
-}
\ No newline at end of file
+	return srv
+}
@@ -0,0 +1,164 @@
+package mirror
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// Quote is the file form of a private quote.
+type Quote struct {
+	ID       string   `json:"id" yaml:"id"`
+	Quote    string   `json:"quote" yaml:"quote"`
+	Author   string   `json:"author,omitempty" yaml:"author,omitempty"`
+	Language string   `json:"language,omitempty" yaml:"language,omitempty"`
+	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// Qshow is the file form of a private qshow.
+type Qshow struct {
+	ID          string   `json:"id" yaml:"id"`
+	Title       string   `json:"title" yaml:"title"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Language    string   `json:"language,omitempty" yaml:"language,omitempty"`
+	Background  string   `json:"background,omitempty" yaml:"background,omitempty"`
+}
+
+// QOD is the file form of a private QOD definition from the local
+// registry.
+type QOD struct {
+	ID          string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Title       string   `json:"title" yaml:"title"`
+	RepeatAfter int      `json:"repeat_after,omitempty" yaml:"repeat_after,omitempty"`
+	Authors     []string `json:"authors,omitempty" yaml:"authors,omitempty"`
+	Language    string   `json:"language,omitempty" yaml:"language,omitempty"`
+	SFW         bool     `json:"sfw" yaml:"sfw"`
+	Private     bool     `json:"private" yaml:"private"`
+	CreatedAt   string   `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// Kind describes one kind of entity kept in the directory.
+type Kind struct {
+	// Name is both the directory name and the name used in reports.
+	Name string
+	// Editable are the fields pushed back upstream. Local edits to any
+	// other field are reverted to the remote value.
+	Editable []string
+	// New returns a pointer to the file form, used to keep field order.
+	New func() interface{}
+	// Key identifies an entity; by default its id.
+	Key func(Entity) string
+	// Fetch lists the remote entities.
+	Fetch func(ctx context.Context) ([]Entity, error)
+	// Push sends the given fields of an entity upstream. Nil for kinds
+	// that are only mirrored.
+	Push func(ctx context.Context, id string, e Entity, fields []string) error
+}
+
+// Kind names.
+const (
+	KindQuotes = "quotes"
+	KindQshows = "qshows"
+	KindQOD    = "qod"
+)
+
+var KindNames = []string{KindQuotes, KindQshows, KindQOD}
+
+// Kinds returns the entity kinds for an account.
+func Kinds(cfg *config.APIConfig) []Kind {
+	c := client.New(cfg)
+	return []Kind{
+		{
+			Name:     KindQuotes,
+			Editable: []string{"quote", "author", "language", "tags"},
+			New:      func() interface{} { return &Quote{} },
+			Fetch: func(ctx context.Context) ([]Entity, error) {
+				quotes, err := c.ListPrivateQuotes(ctx, client.DefaultPageSize)
+				if err != nil {
+					return nil, err
+				}
+				entities := make([]Entity, 0, len(quotes))
+				for _, q := range quotes {
+					entities = append(entities, toEntity(Quote{ID: q.Id, Quote: q.Quote, Author: q.Author, Language: q.Language, Tags: q.Tags}))
+				}
+				return entities, nil
+			},
+			Push: func(ctx context.Context, id string, e Entity, fields []string) error {
+				return c.PatchQuote(ctx, id, fieldValues(e, fields))
+			},
+		},
+		{
+			Name:     KindQshows,
+			Editable: []string{"title", "description", "tags"},
+			New:      func() interface{} { return &Qshow{} },
+			Fetch: func(ctx context.Context) ([]Entity, error) {
+				qshows, err := c.ListQshows(ctx, false)
+				if err != nil {
+					return nil, err
+				}
+				entities := make([]Entity, 0, len(qshows))
+				for _, q := range qshows {
+					entities = append(entities, toEntity(Qshow{ID: q.Id, Title: q.Title, Description: q.Description, Tags: q.Tags, Language: q.Language, Background: q.Background}))
+				}
+				return entities, nil
+			},
+			Push: func(ctx context.Context, id string, e Entity, fields []string) error {
+				return c.PatchQshow(ctx, id, fieldValues(e, fields))
+			},
+		},
+		{
+			Name: KindQOD,
+			New:  func() interface{} { return &QOD{} },
+			Key: func(e Entity) string {
+				if id := client.String(e["id"]); id != "" {
+					return id
+				}
+				return client.String(e["title"])
+			},
+			Fetch: func(ctx context.Context) ([]Entity, error) {
+				defs, err := qodstore.Open(cfg).List(c.Account())
+				if err != nil {
+					return nil, err
+				}
+				entities := make([]Entity, 0, len(defs))
+				for _, d := range defs {
+					entities = append(entities, toEntity(QOD{
+						ID:          d.ID,
+						Title:       d.Title,
+						RepeatAfter: d.RepeatAfter,
+						Authors:     d.Authors,
+						Language:    d.Language,
+						SFW:         d.SFW,
+						Private:     d.Private,
+						CreatedAt:   d.CreatedAt.Format(time.RFC3339),
+						UpdatedAt:   d.UpdatedAt.Format(time.RFC3339),
+					}))
+				}
+				return entities, nil
+			},
+		},
+	}
+}
+
+// fieldValues converts the named fields of an entity to request
+// parameters. Lists become repeated values.
+func fieldValues(e Entity, fields []string) url.Values {
+	params := url.Values{}
+	for _, f := range fields {
+		if list, ok := e[f].([]interface{}); ok {
+			params[f] = client.Strings(list)
+			if len(list) == 0 {
+				params[f] = []string{""}
+			}
+			continue
+		}
+		params.Set(f, client.String(e[f]))
+	}
+	return params
+}
@@ -0,0 +1,525 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+	"gopkg.in/yaml.v3"
+)
+
+// The directory holds one file per entity under a folder per kind
+// (quotes/<id>.yaml, qshows/<id>.yaml, qod/<id>.yaml) and a snapshot of
+// every entity as last seen upstream. Each sync is a three-way merge per
+// field between the local file, the snapshot and the remote entity: a
+// field changed on one side only is taken from that side, a field changed
+// on both sides to different values is a conflict.
+
+// SnapshotFile holds the last-seen remote state, relative to the directory.
+const SnapshotFile = ".sync-snapshot.json"
+
+// File formats.
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+var Formats = []string{FormatYAML, FormatJSON}
+
+// Directions.
+const (
+	DirectionBoth = "both"
+	DirectionPull = "pull"
+	DirectionPush = "push"
+)
+
+var Directions = []string{DirectionBoth, DirectionPull, DirectionPush}
+
+// Conflict policies.
+const (
+	ConflictReport = "report"
+	ConflictLocal  = "local"
+	ConflictRemote = "remote"
+)
+
+var ConflictPolicies = []string{ConflictReport, ConflictLocal, ConflictRemote}
+
+// Item statuses.
+const (
+	StatusUnchanged = "unchanged"
+	StatusCreated   = "created"
+	StatusPulled    = "pulled"
+	StatusPushed    = "pushed"
+	StatusMerged    = "merged"
+	StatusConflict  = "conflict"
+	StatusDeleted   = "deleted"
+	// StatusOrphaned is a file edited locally whose entity was deleted
+	// upstream. The file is kept.
+	StatusOrphaned = "orphaned"
+	// StatusUntracked is a file for an entity that never existed
+	// upstream. Sync does not create entities.
+	StatusUntracked = "untracked"
+	// StatusDeletedLocally is a file removed locally. Sync does not
+	// delete entities upstream.
+	StatusDeletedLocally = "deleted_locally"
+	StatusFailed         = "failed"
+)
+
+// Entity is an entity in its generic form: its file form decoded as JSON.
+type Entity = map[string]interface{}
+
+func toEntity(v interface{}) Entity {
+	raw, _ := json.Marshal(v)
+	e := Entity{}
+	_ = json.Unmarshal(raw, &e)
+	return e
+}
+
+// Options control a sync run.
+type Options struct {
+	Dir        string
+	Format     string
+	Direction  string
+	OnConflict string
+	DryRun     bool
+	// Kinds limits the run to these kinds; all kinds when empty.
+	Kinds []string
+}
+
+// Conflict is a field changed both locally and upstream.
+type Conflict struct {
+	Field  string      `json:"field"`
+	Local  interface{} `json:"local"`
+	Remote interface{} `json:"remote"`
+}
+
+// Item is the outcome for one entity.
+type Item struct {
+	Kind      string     `json:"kind"`
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	File      string     `json:"file,omitempty"`
+	Pushed    []string   `json:"pushed,omitempty"`
+	Pulled    []string   `json:"pulled,omitempty"`
+	Reverted  []string   `json:"reverted,omitempty"`
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Report summarizes a sync run. Unchanged entities are only counted.
+type Report struct {
+	Dir       string         `json:"dir"`
+	Direction string         `json:"direction"`
+	DryRun    bool           `json:"dry_run"`
+	Counts    map[string]int `json:"counts"`
+	Items     []Item         `json:"items"`
+	// Cancelled is set when the call was cancelled before the sync
+	// finished. The snapshot still records what was synced, so the next
+	// run picks up where this one stopped.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+type snapshot map[string]map[string]Entity
+
+// Run syncs the directory with the account.
+func Run(ctx context.Context, kinds []Kind, opts Options) (*Report, error) {
+	if opts.Format == "" {
+		opts.Format = FormatYAML
+	}
+	if opts.Direction == "" {
+		opts.Direction = DirectionBoth
+	}
+	if opts.OnConflict == "" {
+		opts.OnConflict = ConflictReport
+	}
+	snap, err := loadSnapshot(opts.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	report := &Report{Dir: opts.Dir, Direction: opts.Direction, DryRun: opts.DryRun, Counts: map[string]int{}, Items: []Item{}}
+	for _, kind := range kinds {
+		if len(opts.Kinds) > 0 && !contains(opts.Kinds, kind.Name) {
+			continue
+		}
+		if kind.Key == nil {
+			kind.Key = func(e Entity) string { return client.String(e["id"]) }
+		}
+		if snap[kind.Name] == nil {
+			snap[kind.Name] = map[string]Entity{}
+		}
+		if ctx.Err() != nil {
+			report.Cancelled = true
+		}
+		if report.Cancelled {
+			break
+		}
+		if err := syncKind(ctx, kind, opts, snap[kind.Name], report); err != nil {
+			return report, fmt.Errorf("syncing %s: %w", kind.Name, err)
+		}
+	}
+	if !opts.DryRun {
+		if err := saveSnapshot(opts.Dir, snap); err != nil {
+			return report, fmt.Errorf("writing snapshot: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// localFile is an entity file found in the directory.
+type localFile struct {
+	path   string
+	entity Entity
+	err    error
+}
+
+func syncKind(ctx context.Context, kind Kind, opts Options, base map[string]Entity, report *Report) error {
+	remoteList, err := kind.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	remote := map[string]Entity{}
+	for _, e := range remoteList {
+		remote[kind.Key(e)] = e
+	}
+	local, err := readLocal(filepath.Join(opts.Dir, kind.Name), kind)
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]bool{}
+	for _, m := range []map[string]Entity{remote, base} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	for k := range local {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	pull := opts.Direction != DirectionPush
+	push := opts.Direction != DirectionPull && kind.Push != nil
+	progress.Expect(ctx, len(sorted))
+	for _, key := range sorted {
+		if ctx.Err() != nil {
+			report.Cancelled = true
+			return nil
+		}
+		progress.Addf(ctx, 1, "Syncing %s %s", kind.Name, key)
+		r, hasRemote := remote[key]
+		b, hasBase := base[key]
+		l, hasLocal := local[key]
+		item := Item{Kind: kind.Name, ID: key}
+		path := filepath.Join(opts.Dir, kind.Name, fileName(key)+"."+extension(opts.Format))
+		if hasLocal {
+			path = l.path
+		}
+		item.File = path
+
+		switch {
+		case hasLocal && l.err != nil:
+			item.Status, item.Error = StatusFailed, l.err.Error()
+
+		case hasRemote && !hasLocal && !hasBase:
+			item.Status = StatusCreated
+			if pull {
+				base[key] = r
+				if !opts.DryRun {
+					if err := writeEntity(path, kind, r); err != nil {
+						item.Status, item.Error = StatusFailed, err.Error()
+						delete(base, key)
+					}
+				}
+			}
+
+		case hasRemote && !hasLocal:
+			item.Status = StatusDeletedLocally
+
+		case !hasRemote && hasLocal && !hasBase:
+			item.Status = StatusUntracked
+
+		case !hasRemote && hasLocal:
+			if !equal(l.entity, b) {
+				item.Status = StatusOrphaned
+				break
+			}
+			item.Status = StatusDeleted
+			if pull {
+				delete(base, key)
+				if !opts.DryRun {
+					if err := os.Remove(l.path); err != nil {
+						item.Status, item.Error = StatusFailed, err.Error()
+					}
+				}
+			}
+
+		case !hasRemote:
+			// Gone on both sides.
+			delete(base, key)
+			continue
+
+		default:
+			syncEntity(ctx, kind, opts, key, l.entity, b, r, pull, push, base, &item)
+		}
+		report.Counts[item.Status]++
+		if item.Status != StatusUnchanged {
+			report.Items = append(report.Items, item)
+		}
+	}
+	return nil
+}
+
+// syncEntity merges an entity present both locally and upstream.
+func syncEntity(ctx context.Context, kind Kind, opts Options, key string, l, b, r Entity, pull, push bool, base map[string]Entity, item *Item) {
+	l, b, r = prune(l), prune(b), prune(r)
+	merged, next := Entity{}, Entity{}
+	var pushFields []string
+	fields := map[string]bool{}
+	for _, m := range []Entity{l, b, r} {
+		for k := range m {
+			fields[k] = true
+		}
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, f := range names {
+		lv, bv, rv := l[f], b[f], r[f]
+		editable := contains(kind.Editable, f)
+		switch {
+		case reflect.DeepEqual(lv, rv):
+			merged[f], next[f] = lv, rv
+		case !editable:
+			// Read-only fields always follow upstream.
+			merged[f], next[f] = rv, rv
+			if !reflect.DeepEqual(lv, bv) {
+				item.Reverted = append(item.Reverted, f)
+			} else {
+				item.Pulled = append(item.Pulled, f)
+			}
+		case reflect.DeepEqual(lv, bv):
+			merged[f], next[f] = rv, rv
+			item.Pulled = append(item.Pulled, f)
+		case reflect.DeepEqual(rv, bv):
+			merged[f], next[f] = lv, bv
+			if push {
+				pushFields = append(pushFields, f)
+			}
+		case opts.OnConflict == ConflictLocal && push:
+			merged[f], next[f] = lv, bv
+			pushFields = append(pushFields, f)
+		case opts.OnConflict == ConflictRemote && pull:
+			merged[f], next[f] = rv, rv
+			item.Pulled = append(item.Pulled, f)
+		default:
+			// Kept locally and left out of the snapshot, so the conflict
+			// is reported again until it is resolved.
+			merged[f], next[f] = lv, bv
+			item.Conflicts = append(item.Conflicts, Conflict{Field: f, Local: lv, Remote: rv})
+		}
+	}
+
+	if len(pushFields) > 0 {
+		if !opts.DryRun {
+			if err := kind.Push(ctx, key, merged, pushFields); err != nil {
+				item.Status, item.Error = StatusFailed, err.Error()
+				pushFields = nil
+			}
+		}
+		for _, f := range pushFields {
+			next[f] = merged[f]
+		}
+		item.Pushed = pushFields
+	}
+	if !pull {
+		// Upstream changes stay unseen until they are pulled.
+		for _, f := range item.Pulled {
+			next[f] = b[f]
+		}
+		for _, f := range item.Reverted {
+			next[f] = b[f]
+		}
+	}
+	base[key] = prune(next)
+
+	if pull && !opts.DryRun && !equal(merged, l) {
+		if err := writeEntity(item.File, kind, merged); err != nil {
+			item.Status, item.Error = StatusFailed, err.Error()
+		}
+	}
+	if item.Status != "" {
+		return
+	}
+	switch {
+	case len(item.Conflicts) > 0:
+		item.Status = StatusConflict
+	case len(item.Pushed) > 0 && len(item.Pulled) > 0:
+		item.Status = StatusMerged
+	case len(item.Pushed) > 0:
+		item.Status = StatusPushed
+	case len(item.Pulled) > 0 || len(item.Reverted) > 0:
+		item.Status = StatusPulled
+	default:
+		item.Status = StatusUnchanged
+	}
+}
+
+// readLocal decodes every entity file of a kind, keyed by entity key.
+// Files that fail to decode are keyed by file name and carry the error.
+func readLocal(dir string, kind Kind) (map[string]localFile, error) {
+	files := map[string]localFile{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ext := strings.TrimPrefix(filepath.Ext(entry.Name()), ".")
+		if entry.IsDir() || (ext != "yaml" && ext != "yml" && ext != "json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		stem := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			files[stem] = localFile{path: path, err: err}
+			continue
+		}
+		v := kind.New()
+		if err := yaml.Unmarshal(raw, v); err != nil {
+			files[stem] = localFile{path: path, err: err}
+			continue
+		}
+		e := toEntity(v)
+		key := kind.Key(e)
+		if key == "" {
+			key = stem
+		}
+		files[key] = localFile{path: path, entity: e}
+	}
+	return files, nil
+}
+
+func writeEntity(path string, kind Kind, e Entity) error {
+	v := kind.New()
+	raw, _ := json.Marshal(e)
+	if err := json.Unmarshal(raw, v); err != nil {
+		return err
+	}
+	var out []byte
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		out, err = json.MarshalIndent(v, "", "  ")
+		out = append(out, '\n')
+	} else {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err = enc.Encode(v)
+		out = buf.Bytes()
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
+func loadSnapshot(dir string) (snapshot, error) {
+	snap := snapshot{}
+	raw, err := os.ReadFile(filepath.Join(dir, SnapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return snap, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func saveSnapshot(dir string, snap snapshot) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, SnapshotFile), append(raw, '\n'), 0o644)
+}
+
+// equal compares two entities, treating absent and empty fields alike.
+func equal(a, b Entity) bool {
+	return reflect.DeepEqual(prune(a), prune(b))
+}
+
+// prune drops fields that are absent in the file form (nil, "" or an
+// empty list), so both sides of a comparison have the same shape.
+func prune(e Entity) Entity {
+	out := Entity{}
+	for k, v := range e {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string:
+			if val == "" {
+				continue
+			}
+		case []interface{}:
+			if len(val) == 0 {
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// fileName makes an entity key safe to use as a file name.
+func fileName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '-'
+	}, key)
+}
+
+func extension(format string) string {
+	if format == FormatJSON {
+		return "json"
+	}
+	return "yaml"
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
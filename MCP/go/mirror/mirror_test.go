@@ -0,0 +1,349 @@
+package mirror
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func quote(text, author string, tags ...string) Entity {
+	return toEntity(Quote{ID: "q1", Quote: text, Author: author, Tags: tags})
+}
+
+func TestSyncEntity(t *testing.T) {
+	tests := []struct {
+		name       string
+		l, b, r    Entity
+		direction  string
+		onConflict string
+		status     string
+		pushed     []string
+		pulled     []string
+		reverted   []string
+		conflicts  []string
+		// base is the snapshot recorded for the entity.
+		base Entity
+	}{
+		{
+			name:   "unchanged",
+			l:      quote("a", "x"),
+			b:      quote("a", "x"),
+			r:      quote("a", "x"),
+			status: StatusUnchanged,
+			base:   quote("a", "x"),
+		},
+		{
+			name:   "edited locally",
+			l:      quote("b", "x"),
+			b:      quote("a", "x"),
+			r:      quote("a", "x"),
+			status: StatusPushed,
+			pushed: []string{"quote"},
+			base:   quote("b", "x"),
+		},
+		{
+			name:   "edited upstream",
+			l:      quote("a", "x"),
+			b:      quote("a", "x"),
+			r:      quote("a", "y"),
+			status: StatusPulled,
+			pulled: []string{"author"},
+			base:   quote("a", "y"),
+		},
+		{
+			name:   "edited on both sides, different fields",
+			l:      quote("b", "x"),
+			b:      quote("a", "x"),
+			r:      quote("a", "y"),
+			status: StatusMerged,
+			pushed: []string{"quote"},
+			pulled: []string{"author"},
+			base:   quote("b", "y"),
+		},
+		{
+			name:   "edited on both sides to the same value",
+			l:      quote("b", "x"),
+			b:      quote("a", "x"),
+			r:      quote("b", "x"),
+			status: StatusUnchanged,
+			base:   quote("b", "x"),
+		},
+		{
+			name:      "conflict is reported and kept out of the snapshot",
+			l:         quote("b", "x"),
+			b:         quote("a", "x"),
+			r:         quote("c", "x"),
+			status:    StatusConflict,
+			conflicts: []string{"quote"},
+			base:      quote("a", "x"),
+		},
+		{
+			name:       "conflict resolved locally",
+			l:          quote("b", "x"),
+			b:          quote("a", "x"),
+			r:          quote("c", "x"),
+			onConflict: ConflictLocal,
+			status:     StatusPushed,
+			pushed:     []string{"quote"},
+			base:       quote("b", "x"),
+		},
+		{
+			name:       "conflict resolved remotely",
+			l:          quote("b", "x"),
+			b:          quote("a", "x"),
+			r:          quote("c", "x"),
+			onConflict: ConflictRemote,
+			status:     StatusPulled,
+			pulled:     []string{"quote"},
+			base:       quote("c", "x"),
+		},
+		{
+			name:      "pull only keeps local edits unpushed",
+			l:         quote("b", "x"),
+			b:         quote("a", "x"),
+			r:         quote("a", "x"),
+			direction: DirectionPull,
+			status:    StatusUnchanged,
+			base:      quote("a", "x"),
+		},
+		{
+			name:      "push only leaves upstream edits unseen",
+			l:         quote("a", "x"),
+			b:         quote("a", "x"),
+			r:         quote("a", "y"),
+			direction: DirectionPush,
+			status:    StatusPulled,
+			pulled:    []string{"author"},
+			base:      quote("a", "x"),
+		},
+		{
+			name:     "read-only fields follow upstream",
+			l:        toEntity(Quote{ID: "q1", Quote: "a", Author: "x", Language: "fr"}),
+			b:        quote("a", "x"),
+			r:        quote("a", "x"),
+			status:   StatusPulled,
+			reverted: []string{"language"},
+			base:     quote("a", "x"),
+		},
+		{
+			name:   "tags are compared as lists",
+			l:      quote("a", "x", "love", "life"),
+			b:      quote("a", "x", "love"),
+			r:      quote("a", "x", "love"),
+			status: StatusPushed,
+			pushed: []string{"tags"},
+			base:   quote("a", "x", "love", "life"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := Kind{
+				Name:     KindQuotes,
+				Editable: []string{"quote", "author", "tags"},
+				New:      func() interface{} { return &Quote{} },
+				Push:     func(ctx context.Context, id string, e Entity, fields []string) error { return nil },
+			}
+			direction := tt.direction
+			if direction == "" {
+				direction = DirectionBoth
+			}
+			onConflict := tt.onConflict
+			if onConflict == "" {
+				onConflict = ConflictReport
+			}
+			opts := Options{Direction: direction, OnConflict: onConflict, DryRun: true}
+			base := map[string]Entity{}
+			item := Item{Kind: KindQuotes, ID: "q1"}
+			syncEntity(context.Background(), kind, opts, "q1", tt.l, tt.b, tt.r, direction != DirectionPush, direction != DirectionPull, base, &item)
+
+			if item.Status != tt.status {
+				t.Errorf("status = %q, want %q", item.Status, tt.status)
+			}
+			if !slices.Equal(item.Pushed, tt.pushed) {
+				t.Errorf("pushed = %v, want %v", item.Pushed, tt.pushed)
+			}
+			if !slices.Equal(item.Pulled, tt.pulled) {
+				t.Errorf("pulled = %v, want %v", item.Pulled, tt.pulled)
+			}
+			if !slices.Equal(item.Reverted, tt.reverted) {
+				t.Errorf("reverted = %v, want %v", item.Reverted, tt.reverted)
+			}
+			var conflicts []string
+			for _, c := range item.Conflicts {
+				conflicts = append(conflicts, c.Field)
+			}
+			if !slices.Equal(conflicts, tt.conflicts) {
+				t.Errorf("conflicts = %v, want %v", conflicts, tt.conflicts)
+			}
+			if !equal(base["q1"], tt.base) {
+				t.Errorf("snapshot = %v, want %v", base["q1"], prune(tt.base))
+			}
+		})
+	}
+}
+
+// fakeKind keeps quotes in memory, as the API would.
+func fakeKind(remote map[string]Entity) Kind {
+	return Kind{
+		Name:     KindQuotes,
+		Editable: []string{"quote", "author", "language", "tags"},
+		New:      func() interface{} { return &Quote{} },
+		Fetch: func(ctx context.Context) ([]Entity, error) {
+			var out []Entity
+			for _, e := range remote {
+				out = append(out, e)
+			}
+			return out, nil
+		},
+		Push: func(ctx context.Context, id string, e Entity, fields []string) error {
+			for _, f := range fields {
+				remote[id][f] = e[f]
+			}
+			return nil
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	remote := map[string]Entity{"q1": quote("a", "x")}
+	kinds := []Kind{fakeKind(remote)}
+	file := filepath.Join(dir, KindQuotes, "q1.yaml")
+
+	steps := []struct {
+		name   string
+		before func(t *testing.T)
+		opts   Options
+		status string
+		check  func(t *testing.T)
+	}{
+		{
+			name:   "first sync writes the file",
+			status: StatusCreated,
+			check: func(t *testing.T) {
+				raw, err := os.ReadFile(file)
+				if err != nil || !strings.Contains(string(raw), "quote: a") {
+					t.Fatalf("file = %q, %v", raw, err)
+				}
+			},
+		},
+		{
+			name:   "nothing changed",
+			status: "",
+		},
+		{
+			name: "a local edit is pushed",
+			before: func(t *testing.T) {
+				writeFile(t, file, "id: q1\nquote: b\nauthor: x\n")
+			},
+			status: StatusPushed,
+			check: func(t *testing.T) {
+				if remote["q1"]["quote"] != "b" {
+					t.Errorf("remote quote = %v, want b", remote["q1"]["quote"])
+				}
+			},
+		},
+		{
+			name:   "a dry run changes nothing",
+			before: func(t *testing.T) { writeFile(t, file, "id: q1\nquote: c\nauthor: x\n") },
+			opts:   Options{DryRun: true},
+			status: StatusPushed,
+			check: func(t *testing.T) {
+				if remote["q1"]["quote"] != "b" {
+					t.Errorf("remote quote = %v, want b", remote["q1"]["quote"])
+				}
+			},
+		},
+		{
+			name:   "an upstream edit conflicting with a local one is reported",
+			before: func(t *testing.T) { remote["q1"]["quote"] = "d" },
+			status: StatusConflict,
+		},
+		{
+			name:   "the conflict is reported again",
+			status: StatusConflict,
+		},
+		{
+			name:   "the conflict resolved with the remote value is pulled",
+			opts:   Options{OnConflict: ConflictRemote},
+			status: StatusPulled,
+			check: func(t *testing.T) {
+				raw, _ := os.ReadFile(file)
+				if !strings.Contains(string(raw), "quote: d") {
+					t.Errorf("file = %q, want the remote quote", raw)
+				}
+			},
+		},
+		{
+			name:   "a quote deleted upstream removes its unedited file",
+			before: func(t *testing.T) { delete(remote, "q1") },
+			status: StatusDeleted,
+			check: func(t *testing.T) {
+				if _, err := os.Stat(file); !os.IsNotExist(err) {
+					t.Errorf("file still exists: %v", err)
+				}
+			},
+		},
+	}
+	for _, step := range steps {
+		if step.before != nil {
+			step.before(t)
+		}
+		opts := step.opts
+		opts.Dir = dir
+		report, err := Run(context.Background(), kinds, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		status := ""
+		if len(report.Items) > 0 {
+			status = report.Items[0].Status
+		}
+		if status != step.status || len(report.Items) > 1 {
+			t.Fatalf("%s: items = %+v, want one with status %q", step.name, report.Items, step.status)
+		}
+		if step.check != nil {
+			step.check(t)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		in, want Entity
+	}{
+		{Entity{"a": "x", "b": "", "c": nil}, Entity{"a": "x"}},
+		{Entity{"tags": []interface{}{}}, Entity{}},
+		{Entity{"tags": []interface{}{"x"}, "sfw": false}, Entity{"tags": []interface{}{"x"}, "sfw": false}},
+	}
+	for _, tt := range tests {
+		if got := prune(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("prune(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := map[string]string{
+		"abc-DEF_123": "abc-DEF_123",
+		"a/b":         "a-b",
+		"../x":        "---x",
+		"Morning QOD": "Morning-QOD",
+	}
+	for in, want := range tests {
+		if got := fileName(in); got != want {
+			t.Errorf("fileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
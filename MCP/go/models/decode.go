@@ -0,0 +1,268 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The API is loose with types and names: numeric fields such as `length`
+// and `total` arrive as JSON numbers or strings, lists as arrays or comma
+// separated strings, and some fields go by several names. The models decode
+// leniently: scalars are converted to the field's type, the `api` struct
+// tag lists other names a field is read from, and fields a model does not
+// know about are kept in its Extra map instead of being dropped.
+
+// FromMap decodes an already decoded JSON object into the model v points
+// to. It never fails: values that do not fit their field are kept in
+// Extra, when v has one, like unknown fields. A field is read from the
+// first of its names holding a non-empty value.
+func FromMap(m map[string]interface{}, v interface{}) {
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	used := map[string]bool{}
+	var extra reflect.Value
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+		if field.Name == "Extra" && field.Type == reflect.TypeOf(map[string]interface{}{}) {
+			// Extra fields of a model that was encoded before.
+			extra = rv.Field(i)
+			if prev, ok := m[name].(map[string]interface{}); ok {
+				extra.Set(reflect.ValueOf(copyMap(prev)))
+				used[name] = true
+			}
+			continue
+		}
+		done := false
+		for _, key := range append([]string{name}, apiNames(field)...) {
+			val, ok := m[key]
+			if !ok || done {
+				continue
+			}
+			if val == nil {
+				used[key] = true
+				continue
+			}
+			if setLenient(rv.Field(i), val) {
+				used[key] = true
+				done = !isEmpty(rv.Field(i))
+			}
+		}
+	}
+	if n, ok := v.(interface{ normalize() }); ok {
+		n.normalize()
+	}
+	if !extra.IsValid() {
+		return
+	}
+	for key, val := range m {
+		if used[key] {
+			continue
+		}
+		if extra.IsNil() {
+			extra.Set(reflect.ValueOf(map[string]interface{}{}))
+		}
+		extra.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(val))
+	}
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unmarshalLenient implements UnmarshalJSON for the models with FromMap.
+func unmarshalLenient(data []byte, v interface{}) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("%T: %w", v, err)
+	}
+	FromMap(m, v)
+	return nil
+}
+
+// setLenient stores a decoded JSON value in f, converting it to the
+// field's type. It reports whether the value fit.
+func setLenient(f reflect.Value, val interface{}) bool {
+	switch f.Kind() {
+	case reflect.String:
+		if _, ok := val.(map[string]interface{}); ok {
+			return false
+		}
+		if _, ok := val.([]interface{}); ok {
+			return false
+		}
+		f.SetString(String(val))
+		return true
+	case reflect.Int, reflect.Int64:
+		n, ok := toInt(val)
+		if ok {
+			f.SetInt(int64(n))
+		}
+		return ok
+	case reflect.Bool:
+		b, ok := toBool(val)
+		if ok {
+			f.SetBool(b)
+		}
+		return ok
+	case reflect.Slice:
+		if f.Type().Elem().Kind() == reflect.String {
+			switch val.(type) {
+			case []interface{}, string:
+				f.Set(reflect.ValueOf(Strings(val)))
+				return true
+			}
+			return false
+		}
+		// Items that do not decode are skipped rather than failing the
+		// whole list.
+		items, ok := val.([]interface{})
+		if ok && !reflect.PointerTo(f.Type()).Implements(unmarshalerType) {
+			out := reflect.MakeSlice(f.Type(), 0, len(items))
+			for _, item := range items {
+				elem := reflect.New(f.Type().Elem())
+				if item != nil && setLenient(elem.Elem(), item) {
+					out = reflect.Append(out, elem.Elem())
+				}
+			}
+			f.Set(out)
+			return true
+		}
+	}
+	// Nested models and anything else go through encoding/json, so their
+	// own UnmarshalJSON applies.
+	data, err := json.Marshal(val)
+	if err != nil {
+		return false
+	}
+	ptr := reflect.New(f.Type())
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return false
+	}
+	f.Set(ptr.Elem())
+	return true
+}
+
+func isEmpty(f reflect.Value) bool {
+	switch f.Kind() {
+	case reflect.Slice, reflect.Map:
+		return f.Len() == 0
+	}
+	return f.IsZero()
+}
+
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+func apiNames(field reflect.StructField) []string {
+	tag := field.Tag.Get("api")
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// String converts a decoded JSON scalar to a string. nil becomes "".
+func String(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// Int converts a decoded JSON number or numeric string to an int.
+func Int(v interface{}) int {
+	n, _ := toInt(v)
+	return n
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch val := v.(type) {
+	case float64:
+		return int(val), true
+	case int:
+		return val, true
+	case string:
+		if strings.TrimSpace(val) == "" {
+			return 0, true
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func toBool(v interface{}) (bool, bool) {
+	switch val := v.(type) {
+	case bool:
+		return val, true
+	case float64:
+		return val != 0, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(val)) {
+		case "true", "1", "yes":
+			return true, true
+		case "false", "0", "no", "":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// Strings converts a decoded JSON array (or a comma separated string) to
+// a string slice.
+func Strings(v interface{}) []string {
+	switch val := v.(type) {
+	case []interface{}:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s := String(item); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	case string:
+		return SplitList(val)
+	}
+	return nil
+}
+
+// SplitList splits a comma separated list, trimming blanks.
+func SplitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
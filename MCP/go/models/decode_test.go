@@ -0,0 +1,99 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromMap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   map[string]interface{}
+		// v is the zero model to decode into and want what it should hold.
+		v, want interface{}
+	}{
+		{
+			name: "fields by their json names",
+			in:   map[string]interface{}{"id": "q1", "quote": "Be.", "author": "Anon", "language": "en"},
+			v:    &Quote{},
+			want: &Quote{Id: "q1", Quote: "Be.", Author: "Anon", Language: "en"},
+		},
+		{
+			name: "numbers become strings and numeric strings numbers",
+			in:   map[string]interface{}{"id": float64(42), "quote": "Be.", "length": "3"},
+			v:    &Quote{},
+			want: &Quote{Id: "42", Quote: "Be.", Length: 3},
+		},
+		{
+			name: "lists from arrays or comma separated strings",
+			in:   map[string]interface{}{"id": "s1", "title": "T", "tags": "love, life,"},
+			v:    &Qshow{},
+			want: &Qshow{Id: "s1", Title: "T", Tags: []string{"love", "life"}},
+		},
+		{
+			name: "other names from the api tag",
+			in:   map[string]interface{}{"id": "q1", "quote": "Be.", "categories": []interface{}{"love", float64(7)}},
+			v:    &Quote{},
+			want: &Quote{Id: "q1", Quote: "Be.", Tags: []string{"love", "7"}},
+		},
+		{
+			name: "the first non-empty name wins",
+			in:   map[string]interface{}{"id": "q1", "quote": "Be.", "tags": []interface{}{}, "categories": []interface{}{"love"}},
+			v:    &Quote{},
+			want: &Quote{Id: "q1", Quote: "Be.", Tags: []string{"love"}},
+		},
+		{
+			name: "unknown fields are kept in Extra",
+			in:   map[string]interface{}{"id": "q1", "quote": "Be.", "permalink": "https://x/q1"},
+			v:    &Quote{},
+			want: &Quote{Id: "q1", Quote: "Be.", Extra: map[string]interface{}{"permalink": "https://x/q1"}},
+		},
+		{
+			name: "values that do not fit are kept in Extra",
+			in:   map[string]interface{}{"id": "q1", "quote": "Be.", "length": "long"},
+			v:    &Quote{},
+			want: &Quote{Id: "q1", Quote: "Be.", Extra: map[string]interface{}{"length": "long"}},
+		},
+		{
+			name: "Extra of a model encoded before is read back",
+			in:   map[string]interface{}{"id": "q1", "quote": "Be.", "extra": map[string]interface{}{"permalink": "p"}, "sfw": true},
+			v:    &Quote{},
+			want: &Quote{Id: "q1", Quote: "Be.", Extra: map[string]interface{}{"permalink": "p", "sfw": true}},
+		},
+		{
+			name: "nulls are ignored",
+			in:   map[string]interface{}{"id": "q1", "quote": nil, "author": nil},
+			v:    &Quote{},
+			want: &Quote{Id: "q1"},
+		},
+		{
+			name: "models normalize after decoding",
+			in:   map[string]interface{}{"id": "a1", "name": "Mark Twain", "birthday": "1835-11-30 00:00:00", "dead": "1910-04-21"},
+			v:    &Author{},
+			want: &Author{Id: "a1", Name: "Mark Twain", Born: "1835-11-30", Dead: "1910-04-21"},
+		},
+		{
+			name: "nested models decode leniently",
+			in: map[string]interface{}{
+				"success":  map[string]interface{}{"total": "2"},
+				"contents": map[string]interface{}{"id": "q1", "quote": "Be.", "length": float64(3)},
+			},
+			v:    &Response[Quote]{},
+			want: &Response[Quote]{Success: Success{Total: 2}, Contents: Quote{Id: "q1", Quote: "Be.", Length: 3}},
+		},
+		{
+			name: "mutations answer with content",
+			in:   map[string]interface{}{"content": map[string]interface{}{"id": "q1", "quote": "Be."}},
+			v:    &Response[Quote]{},
+			want: &Response[Quote]{Contents: Quote{Id: "q1", Quote: "Be."}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			FromMap(tt.in, tt.v)
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("FromMap(%v) = %+v, want %+v", tt.in, tt.v, tt.want)
+			}
+		})
+	}
+}
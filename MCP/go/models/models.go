@@ -2,6 +2,10 @@ package models
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -10,28 +14,55 @@ type Tool struct {
 	Handler    func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
 
+type ResourceTemplate struct {
+	Definition mcp.ResourceTemplate
+	Handler    func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)
+}
+
+type Prompt struct {
+	Definition mcp.Prompt
+	Handler    func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+}
+
 // Quote represents the Quote schema from the OpenAPI specification
 type Quote struct {
-	Tags []string `json:"tags,omitempty"` // Array of tags/categories.
+	Tags []string `json:"tags,omitempty" api:"categories"` // Array of tags/categories.
 	Author string `json:"author,omitempty"` // Author name of quote.
 	Quote string `json:"quote"` // The Quote.
 	Id string `json:"id"` // Unique identifier representing a specific quote in theysaidso.com.
 	Image string `json:"image,omitempty"` // Image URL that can be used for background to display this quote.
 	Length int `json:"length,omitempty"` // Length of the quote string.
+	Language string `json:"language,omitempty"` // Language of the quote.
+	Extra map[string]interface{} `json:"extra,omitempty"` // Fields the API returned that are not modeled here.
 }
 
-// QuoteResponse represents the QuoteResponse schema from the OpenAPI specification
-type QuoteResponse struct {
-	Contents interface{} `json:"contents,omitempty"`
-	Success string `json:"success,omitempty"` // Metadata about this successful call
+func (q *Quote) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, q) }
+
+// Success is the metadata of a successful call.
+type Success struct {
+	Total int `json:"total,omitempty"` // Number of matches, on listings.
+	Extra map[string]interface{} `json:"extra,omitempty"`
 }
 
-// SuccessResponse represents the SuccessResponse schema from the OpenAPI specification
-type SuccessResponse struct {
-	Contents []interface{} `json:"contents,omitempty"` // Contents relevant to this successful call
-	Success string `json:"success,omitempty"` // Metadata about this successful call
+func (s *Success) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, s) }
+
+// Response is the envelope of a successful API response. Mutations answer
+// with `content` where reads use `contents`; both are read into Contents.
+type Response[T any] struct {
+	Success Success `json:"success"` // Metadata about this successful call
+	Contents T `json:"contents" api:"content"`
 }
 
+func (r *Response[T]) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, r) }
+
+// QuoteResponse represents the QuoteResponse schema from the OpenAPI specification
+type QuoteResponse = Response[QuoteList]
+
+// SuccessResponse represents the SuccessResponse schema from the OpenAPI
+// specification: the response of a mutation. Its contents differ per
+// endpoint.
+type SuccessResponse = Response[map[string]interface{}]
+
 // NewQuote represents the NewQuote schema from the OpenAPI specification
 type NewQuote struct {
 	Tags []string `json:"tags,omitempty"` // Array of tags/categories.
@@ -41,18 +72,192 @@ type NewQuote struct {
 
 // QOD represents the QOD schema from the OpenAPI specification
 type QOD struct {
-	Tags []string `json:"tags,omitempty"` // Array of tags/categories.
+	Tags []string `json:"tags,omitempty" api:"categories"` // Array of tags/categories.
 	Author string `json:"author,omitempty"` // Author name of quote.
 	Quote string `json:"quote"` // The Quote.
 	Length int `json:"length,omitempty"` // Length of the quote string.
 	Id string `json:"id"` // Unique identifier representing a specific quote in theysaidso.com.
-	Image string `json:"image,omitempty"` // Image URL that can be used for background to display this quote.
+	Image string `json:"image,omitempty" api:"background"` // Image URL that can be used for background to display this quote.
 	Date string `json:"date"` // Date this quote of the day belongs to
 	Title string `json:"title,omitempty"` // Title of the QOD category
+	Extra map[string]interface{} `json:"extra,omitempty"` // Fields the API returned that are not modeled here.
+}
+
+func (q *QOD) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, q) }
+
+// QODList is the contents of a quote of the day response.
+type QODList struct {
+	Quotes []QOD `json:"quotes"`
 }
 
 // QODResponse represents the QODResponse schema from the OpenAPI specification
-type QODResponse struct {
-	Success string `json:"success,omitempty"` // Metadata about this successful call
-	Contents interface{} `json:"contents,omitempty"`
+type QODResponse = Response[QODList]
+
+// Qshow is a qshow, a titled and ordered collection of quotes.
+type Qshow struct {
+	Id          string                 `json:"id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description,omitempty"`
+	Background  string                 `json:"background,omitempty"`
+	Language    string                 `json:"language,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (q *Qshow) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, q) }
+
+// QshowResponse is the response of /qshow/quotes.
+type QshowResponse = Response[QshowQuotes]
+
+// QshowListResponse is the response of /qshow/list.
+type QshowListResponse = Response[QshowList]
+
+// Author is an author as returned by the author search and popular
+// listings. The biographical fields are only filled in for `detailed`
+// searches, which not every subscription level allows.
+type Author struct {
+	Id          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Slug        string                 `json:"slug,omitempty"`
+	Occupation  string                 `json:"occupation,omitempty"`
+	Born        string                 `json:"born,omitempty" api:"birthday"`
+	Dead        string                 `json:"dead,omitempty" api:"death_date"`
+	Description string                 `json:"description,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (a *Author) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, a) }
+
+// normalize trims the midnight time the API appends to dates.
+func (a *Author) normalize() {
+	a.Born = strings.TrimSuffix(a.Born, " 00:00:00")
+	a.Dead = strings.TrimSuffix(a.Dead, " 00:00:00")
+}
+
+// AuthorListResponse is the response of the author search and popular
+// listings.
+type AuthorListResponse = Response[AuthorList]
+
+// Category is a quote or QOD category. Description, Language and
+// Background are only filled in for `detailed` QOD category listings.
+type Category struct {
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Language    string                 `json:"language,omitempty"`
+	Background  string                 `json:"background,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare category name.
+func (c *Category) UnmarshalJSON(data []byte) error {
+	var name string
+	if json.Unmarshal(data, &name) == nil {
+		*c = Category{Name: name}
+		return nil
+	}
+	return unmarshalLenient(data, c)
+}
+
+func (c *Category) normalize() {
+	c.Title = strings.TrimSpace(c.Title)
+}
+
+// Categories is a list of categories. The API lists them as an array of
+// objects or names, or, like the plain format of /qod/categories, as an
+// object mapping names to titles or to detailed objects; the latter is
+// sorted by name.
+type Categories []Category
+
+func (cs *Categories) UnmarshalJSON(data []byte) error {
+	var byName map[string]json.RawMessage
+	if json.Unmarshal(data, &byName) == nil {
+		categories := make(Categories, 0, len(byName))
+		for name, raw := range byName {
+			c := Category{Name: name}
+			var title string
+			if json.Unmarshal(raw, &title) == nil {
+				c.Title = strings.TrimSpace(title)
+			} else if err := json.Unmarshal(raw, &c); err != nil {
+				return err
+			} else if c.Name == "" {
+				c.Name = name
+			}
+			categories = append(categories, c)
+		}
+		sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+		*cs = categories
+		return nil
+	}
+	var list []Category
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	categories := make(Categories, 0, len(list))
+	for _, c := range list {
+		if c.Name != "" {
+			categories = append(categories, c)
+		}
+	}
+	*cs = categories
+	return nil
+}
+
+// CategoryListResponse is the response of the category listings.
+type CategoryListResponse = Response[CategoryList]
+
+// QuoteImage is a rendered quote image, as returned by the image search.
+// Image holds the base64 encoded file when it was asked for inline, with
+// binary=false.
+type QuoteImage struct {
+	Id          string                 `json:"id"`
+	QuoteID     string                 `json:"quote_id,omitempty"`
+	Permalink   string                 `json:"permalink,omitempty"`
+	DownloadURI string                 `json:"download_uri,omitempty"`
+	Image       string                 `json:"image,omitempty" api:"base64,data,image_data"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (q *QuoteImage) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, q) }
+
+// Asset is a background image or font used to render quote images.
+type Asset struct {
+	Id          string                 `json:"id"`
+	Name        string                 `json:"name,omitempty" api:"title"`
+	Tags        []string               `json:"tags,omitempty"`
+	Permalink   string                 `json:"permalink,omitempty"`
+	DownloadURI string                 `json:"download_uri,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+func (a *Asset) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, a) }
+
+// Background is an uploaded background image.
+type Background = Asset
+
+// Font is an uploaded font.
+type Font = Asset
+
+// ErrorResponse is the body of a failed API call.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+func (e *ErrorResponse) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, e) }
+
+// ErrorDetail describes why an API call failed.
+type ErrorDetail struct {
+	Code    int                    `json:"code,omitempty"`
+	Message string                 `json:"message,omitempty" api:"mesg,msg"`
+	Extra   map[string]interface{} `json:"extra,omitempty"`
+}
+
+// UnmarshalJSON also accepts a bare error message.
+func (e *ErrorDetail) UnmarshalJSON(data []byte) error {
+	var message string
+	if json.Unmarshal(data, &message) == nil {
+		*e = ErrorDetail{Message: message}
+		return nil
+	}
+	return unmarshalLenient(data, e)
 }
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// The types below are the structured results of the tools that wrap a
+// single endpoint. Tools declare them as their output schema, so clients
+// know the shape of a result without parsing its text. They decode
+// leniently from the API's contents, like the models in models.go.
+
+// QuoteList is a page of quotes.
+type QuoteList struct {
+	Quotes []Quote `json:"quotes"`
+	// Total is the number of matches upstream, when the API reports it.
+	Total int `json:"total,omitempty"`
+}
+
+func (l *QuoteList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// QshowList is a page of qshows.
+type QshowList struct {
+	Qshows []Qshow `json:"qshows"`
+	Total  int     `json:"total,omitempty"`
+}
+
+func (l *QshowList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// QshowQuotes is a qshow with its quotes in order.
+type QshowQuotes struct {
+	Qshow  Qshow   `json:"qshow"`
+	Quotes []Quote `json:"quotes"`
+}
+
+func (q *QshowQuotes) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, q) }
+
+// AuthorList is a page of authors.
+type AuthorList struct {
+	Authors []Author `json:"authors"`
+	Total   int      `json:"total,omitempty"`
+}
+
+func (l *AuthorList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// CategoryList is a page of quote or QOD categories.
+type CategoryList struct {
+	Categories Categories `json:"categories"`
+	Total      int        `json:"total,omitempty"`
+}
+
+func (l *CategoryList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// LanguageList lists language codes.
+type LanguageList struct {
+	Languages []string `json:"languages"`
+}
+
+func (l *LanguageList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// QuoteImageList is a page of quote images.
+type QuoteImageList struct {
+	Images []QuoteImage `json:"images"`
+	Total  int          `json:"total,omitempty"`
+}
+
+func (l *QuoteImageList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// BackgroundList is a page of background images.
+type BackgroundList struct {
+	Backgrounds []Asset `json:"backgrounds"`
+	Total       int     `json:"total,omitempty"`
+}
+
+func (l *BackgroundList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// FontList is a page of fonts.
+type FontList struct {
+	Fonts []Asset `json:"fonts"`
+	Total int     `json:"total,omitempty"`
+}
+
+func (l *FontList) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, l) }
+
+// Status is the result of a create, update or tagging call: the id of the
+// entity it created or changed, when the API returns one, and its message.
+type Status struct {
+	Id      string `json:"id,omitempty"`
+	Message string `json:"message,omitempty" api:"msg,mesg"`
+}
+
+func (s *Status) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, s) }
+
+// Toggle is the result of liking or bookmarking a quote. The call flips
+// the state, so WasSet tells whether the quote was liked or bookmarked
+// before it.
+type Toggle struct {
+	Message string `json:"message,omitempty" api:"mesg"`
+	WasSet  bool   `json:"was_set" api:"hasLikedBefore,hasBookmarkedBefore"`
+}
+
+func (t *Toggle) UnmarshalJSON(data []byte) error { return unmarshalLenient(data, t) }
+
+// ToolResult returns v as the structured content of a tool result, with
+// its pretty-printed JSON as the text for clients that do not read
+// structured content. v must encode to a JSON object.
+func ToolResult(v interface{}) *mcp.CallToolResult {
+	prettyJSON, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to format JSON", err)
+	}
+	return mcp.NewToolResultStructured(v, string(prettyJSON))
+}
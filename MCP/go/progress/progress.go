@@ -0,0 +1,118 @@
+// Package progress reports how far long-running tool calls have got, as
+// MCP progress notifications, and lets clients cancel them.
+//
+// Handlers and the packages they call report through the context of the
+// call, so code deep in a paged listing or a batch does not need to know
+// which tool it runs for. Without a progress token in the request every
+// call here is a no-op.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinInterval is the least time between two notifications of a call.
+// Updates in between are folded into the next one; the one completing the
+// expected total is always sent.
+const MinInterval = 250 * time.Millisecond
+
+type reporterKey struct{}
+
+// Reporter sends the progress notifications of one tool call. Progress is
+// a running count of finished work items. The total is what the call
+// expects to do, and grows as later phases discover more work, so
+// progress never goes backwards.
+type Reporter struct {
+	ctx   context.Context
+	srv   *server.MCPServer
+	token mcp.ProgressToken
+
+	mu    sync.Mutex
+	done  int
+	total int
+	sent  time.Time
+}
+
+// WithReporter returns ctx with a Reporter for request, when the request
+// carries a progress token and ctx a server to send notifications with.
+func WithReporter(ctx context.Context, request mcp.CallToolRequest) context.Context {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return ctx
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return ctx
+	}
+	r := &Reporter{ctx: ctx, srv: srv, token: request.Params.Meta.ProgressToken}
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// FromContext returns the Reporter of the call, or nil.
+func FromContext(ctx context.Context) *Reporter {
+	r, _ := ctx.Value(reporterKey{}).(*Reporter)
+	return r
+}
+
+// Expect adds n work items to the total the call expects to do.
+func Expect(ctx context.Context, n int) {
+	if r := FromContext(ctx); r != nil && n > 0 {
+		r.mu.Lock()
+		r.total += n
+		r.mu.Unlock()
+	}
+}
+
+// Add records n finished work items and reports them with message.
+func Add(ctx context.Context, n int, message string) {
+	if r := FromContext(ctx); r != nil {
+		r.add(n, message)
+	}
+}
+
+// Addf is Add with a formatted message.
+func Addf(ctx context.Context, n int, format string, args ...interface{}) {
+	if r := FromContext(ctx); r != nil {
+		r.add(n, fmt.Sprintf(format, args...))
+	}
+}
+
+func (r *Reporter) add(n int, message string) {
+	r.mu.Lock()
+	r.done += n
+	if r.total > 0 && r.done > r.total {
+		r.total = r.done
+	}
+	now := time.Now()
+	if now.Sub(r.sent) < MinInterval && r.done != r.total {
+		r.mu.Unlock()
+		return
+	}
+	r.sent = now
+	params := map[string]any{
+		"progressToken": r.token,
+		"progress":      r.done,
+	}
+	if r.total > 0 {
+		params["total"] = r.total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	r.mu.Unlock()
+
+	// The client may be gone; the call goes on regardless.
+	_ = r.srv.SendNotificationToClient(r.ctx, "notifications/progress", params)
+}
+
+// Middleware gives every tool call a Reporter when it asked for progress.
+func Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(WithReporter(ctx, request), request)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/prompts"
+)
+
+// promptTools names the tools whose data a prompt fetches and the tools it
+// tells the assistant to call. Prompts with a tool that is not exposed,
+// because of the toolsets, read-only mode or the capabilities, are left
+// out.
+var promptTools = map[string][]string{
+	"daily_inspiration": {"get_qod", "get_qod_categories", "create_quote_card"},
+	"build_qshow":       {"get_quote_search", "get_qshow_list", "build_qshow"},
+	"brand_quote_card":  {"get_quote_image_background_list", "get_quote_image_font_list", "create_quote_card"},
+	"curate_author":     {"get_author_profile", "get_quote_search", "build_qshow", "put_quote"},
+}
+
+func GetAllPrompts(cfg *config.APIConfig, tools []models.Tool) []models.Prompt {
+	exposed := map[string]bool{}
+	for _, tool := range tools {
+		exposed[tool.Definition.Name] = true
+	}
+	var out []models.Prompt
+	for _, prompt := range []models.Prompt{
+		prompts.CreateDaily_inspirationPrompt(cfg),
+		prompts.CreateBuild_qshowPrompt(cfg),
+		prompts.CreateBrand_quote_cardPrompt(cfg),
+		prompts.CreateCurate_authorPrompt(cfg),
+	} {
+		if !slices.ContainsFunc(promptTools[prompt.Definition.Name], func(tool string) bool { return !exposed[tool] }) {
+			out = append(out, prompt)
+		}
+	}
+	return out
+}
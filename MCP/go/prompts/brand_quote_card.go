@@ -0,0 +1,73 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Brand_quote_cardHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		brand := arg(request, "brand", "")
+		if brand == "" {
+			return nil, fmt.Errorf("brand is required")
+		}
+		subject := "a quote"
+		if id := arg(request, "quote_id", ""); id != "" {
+			subject = fmt.Sprintf("quote %s (pass it as `quote_id`)", id)
+		} else if topic := arg(request, "topic", ""); topic != "" {
+			subject = fmt.Sprintf("a quote about %q (pass it as `topic`)", topic)
+		}
+		size := arg(request, "size", "square")
+		c := client.New(cfg)
+
+		messages := []mcp.PromptMessage{instructions(
+			fmt.Sprintf("Design a %s quote card for %s in this brand style: %s", size, subject, brand),
+			"1. Pick the backgrounds and fonts below whose names or tags fit the brand. Prefer them over public assets.",
+			"   If none fit, derive two or three mood tags from the brand style instead.",
+			"2. Translate the brand colors into `text_color` (and `bg_color` when no background fits).",
+			fmt.Sprintf("3. Call `create_quote_card` with `size` %q, `mood`, and `bgimage_id` and `font_id` when you picked them. Set `branding` to false.", size),
+			"4. Show me the card and explain the choices in one or two sentences. Offer a variation with a different `seed`.",
+		)}
+
+		for _, pinned := range []struct{ name, what string }{{"bgimage_id", "background"}, {"font_id", "font"}} {
+			if id := arg(request, pinned.name, ""); id != "" {
+				messages = append(messages, instructions(fmt.Sprintf("Use the %s %s: pass it as `%s`.", pinned.what, id, pinned.name)))
+			}
+		}
+
+		for _, kind := range []struct{ asset, title, fallback string }{
+			{client.AssetBackground, "My backgrounds", "get_quote_image_background_list"},
+			{client.AssetFont, "My fonts", "get_quote_image_font_list"},
+		} {
+			assets, err := c.ListAssets(ctx, kind.asset)
+			if assets == nil {
+				assets = []models.Asset{}
+			}
+			messages = append(messages, data(kind.title, assets, err, kind.fallback))
+		}
+
+		return mcp.NewGetPromptResult("Quote card in brand style", messages), nil
+	}
+}
+
+func CreateBrand_quote_cardPrompt(cfg *config.APIConfig) models.Prompt {
+	prompt := mcp.NewPrompt("brand_quote_card",
+		mcp.WithPromptDescription("Design a quote card in a brand style using the account's own backgrounds and fonts"),
+		mcp.WithArgument("brand", mcp.RequiredArgument(), mcp.ArgumentDescription("Brand style: colors, tone, typography, e.g. \"navy and gold, calm, serif\"")),
+		mcp.WithArgument("topic", mcp.ArgumentDescription("Topic of the quote")),
+		mcp.WithArgument("quote_id", mcp.ArgumentDescription("Use this quote instead of searching by topic")),
+		mcp.WithArgument("bgimage_id", mcp.ArgumentDescription("Use this background")),
+		mcp.WithArgument("font_id", mcp.ArgumentDescription("Use this font")),
+		mcp.WithArgument("size", mcp.ArgumentDescription("square, portrait, landscape, story or WIDTHxHEIGHT. Defaults to square.")),
+	)
+
+	return models.Prompt{
+		Definition: prompt,
+		Handler:    Brand_quote_cardHandler(cfg),
+	}
+}
@@ -0,0 +1,66 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Build_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		topic := arg(request, "topic", "")
+		if topic == "" {
+			return nil, fmt.Errorf("topic is required")
+		}
+		count := intArg(request, "count", 10, 50)
+		language := arg(request, "language", "en")
+		c := client.New(cfg)
+
+		messages := []mcp.PromptMessage{instructions(
+			fmt.Sprintf("Build a qshow (a slideshow of quotes) about %q with %d quotes.", topic, count),
+			"1. From the candidates below, pick the quotes that fit the topic best, varied in author and tone, and order them so the show builds up.",
+			"   If there are too few good ones, call `get_quote_search` with related keywords.",
+			"2. Choose a title that is not already used by one of my qshows, a one-sentence description and a few tags.",
+			"3. Show me the plan, then call `build_qshow` with `title`, `description`, `tags` and `quote_ids` in order.",
+		)}
+
+		quotes, err := c.SearchQuotes(ctx, url.Values{
+			"query":    {topic},
+			"language": {language},
+			"limit":    {strconv.Itoa(count * 2)},
+		})
+		if client.IsNotFound(err) {
+			quotes, err = []models.Quote{}, nil
+		}
+		messages = append(messages, data("Candidate quotes", quotes, err, "get_quote_search"))
+
+		qshows, err := c.ListQshows(ctx, false)
+		titles := make([]string, 0, len(qshows))
+		for _, q := range qshows {
+			titles = append(titles, q.Title)
+		}
+		messages = append(messages, data("Titles of my existing qshows", titles, err, "get_qshow_list"))
+
+		return mcp.NewGetPromptResult(fmt.Sprintf("Build a qshow about %s", topic), messages), nil
+	}
+}
+
+func CreateBuild_qshowPrompt(cfg *config.APIConfig) models.Prompt {
+	prompt := mcp.NewPrompt("build_qshow",
+		mcp.WithPromptDescription("Plan and create a qshow about a topic from live quote search results"),
+		mcp.WithArgument("topic", mcp.RequiredArgument(), mcp.ArgumentDescription("What the qshow is about")),
+		mcp.WithArgument("count", mcp.ArgumentDescription("Number of quotes. Defaults to 10.")),
+		mcp.WithArgument("language", mcp.ArgumentDescription("Language of the quotes. Defaults to en.")),
+	)
+
+	return models.Prompt{
+		Definition: prompt,
+		Handler:    Build_qshowHandler(cfg),
+	}
+}
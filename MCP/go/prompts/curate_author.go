@@ -0,0 +1,76 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Curate_authorHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		author := arg(request, "author", "")
+		if author == "" {
+			return nil, fmt.Errorf("author is required")
+		}
+		count := intArg(request, "count", 10, 50)
+		language := arg(request, "language", "en")
+		c := client.New(cfg)
+
+		messages := []mcp.PromptMessage{instructions(
+			fmt.Sprintf("Curate the %d best quotes by %s.", count, author),
+			"1. From the quotes below, leave out near-duplicates and misattributions, and pick a varied set that represents the author well.",
+			"   If there are too few, call `get_quote_search` with `author` and other keywords.",
+			"2. List the picks with a one-line note on each, grouped by theme.",
+			"3. Offer to save them: `build_qshow` with the `quote_ids` for a slideshow, or `put_quote` to copy them into my private collection with a tag for the author.",
+		)}
+
+		authors, err := c.SearchAuthors(ctx, author, language, true, 3)
+		switch {
+		case client.IsTierError(err):
+			messages = append(messages, instructions("Author details are not available on this subscription tier."))
+		case err == nil && len(authors) == 0:
+			messages = append(messages, instructions(fmt.Sprintf("No author named %q was found; the quote search below may still match.", author)))
+		default:
+			for _, a := range authors {
+				if strings.EqualFold(a.Name, author) {
+					authors = []models.Author{a}
+					break
+				}
+			}
+			messages = append(messages, data("About the author", authors, err, "get_author_profile"))
+		}
+
+		quotes, err := c.SearchQuotes(ctx, url.Values{
+			"author":   {author},
+			"language": {language},
+			"limit":    {strconv.Itoa(count * 3)},
+		})
+		if client.IsNotFound(err) {
+			quotes, err = []models.Quote{}, nil
+		}
+		messages = append(messages, data("Quotes by "+author, quotes, err, "get_quote_search"))
+
+		return mcp.NewGetPromptResult(fmt.Sprintf("Curate quotes by %s", author), messages), nil
+	}
+}
+
+func CreateCurate_authorPrompt(cfg *config.APIConfig) models.Prompt {
+	prompt := mcp.NewPrompt("curate_author",
+		mcp.WithPromptDescription("Curate the best quotes by an author from live search results"),
+		mcp.WithArgument("author", mcp.RequiredArgument(), mcp.ArgumentDescription("Author name")),
+		mcp.WithArgument("count", mcp.ArgumentDescription("Number of quotes to pick. Defaults to 10.")),
+		mcp.WithArgument("language", mcp.ArgumentDescription("Language of the quotes. Defaults to en.")),
+	)
+
+	return models.Prompt{
+		Definition: prompt,
+		Handler:    Curate_authorHandler(cfg),
+	}
+}
@@ -0,0 +1,63 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Daily_inspirationHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		category := arg(request, "category", "inspire")
+		language := arg(request, "language", "en")
+		c := client.New(cfg)
+
+		messages := []mcp.PromptMessage{instructions(
+			fmt.Sprintf("Share today's %q quote of the day with me.", category),
+			"Quote it exactly with its author, then add two or three sentences on how I could apply it today.",
+			"If I want it as an image, call `create_quote_card` with its `quote_id`.",
+			"If the category does not exist, suggest the closest of the available categories below.",
+		)}
+
+		uri := fmt.Sprintf("qod://%s/%s", category, language)
+		qod, err := c.QOD(ctx, category, language, "")
+		if err != nil {
+			messages = append(messages, data("Today's quote of the day", nil, err, "get_qod"))
+		} else if text, err := json.MarshalIndent(qod, "", "  "); err == nil {
+			messages = append(messages, mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(text),
+			})))
+		}
+
+		categories, err := c.QODCategories(ctx, language)
+		names := make([]string, 0, len(categories))
+		for name := range categories {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		messages = append(messages, data("Available QOD categories", names, err, "get_qod_categories"))
+
+		return mcp.NewGetPromptResult(fmt.Sprintf("Daily inspiration: %s", category), messages), nil
+	}
+}
+
+func CreateDaily_inspirationPrompt(cfg *config.APIConfig) models.Prompt {
+	prompt := mcp.NewPrompt("daily_inspiration",
+		mcp.WithPromptDescription("Today's quote of the day for a category, with a short reflection"),
+		mcp.WithArgument("category", mcp.ArgumentDescription("QOD category, e.g. inspire, life, funny. Defaults to inspire.")),
+		mcp.WithArgument("language", mcp.ArgumentDescription("Language of the QOD. Defaults to en.")),
+	)
+
+	return models.Prompt{
+		Definition: prompt,
+		Handler:    Daily_inspirationHandler(cfg),
+	}
+}
@@ -0,0 +1,54 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Prompts are one-click workflows for MCP clients. Each one fetches live
+// data through the same client as the tools and tells the assistant which
+// tools to call next. A failed fetch does not fail the prompt: the message
+// names the tool to call for that data instead.
+
+// arg returns a prompt argument, or def when it is empty.
+func arg(request mcp.GetPromptRequest, name, def string) string {
+	if v := strings.TrimSpace(request.Params.Arguments[name]); v != "" {
+		return v
+	}
+	return def
+}
+
+// intArg returns a numeric prompt argument clamped to [1, max], or def
+// when it is empty or not a number.
+func intArg(request mcp.GetPromptRequest, name string, def, max int) int {
+	n, err := strconv.Atoi(arg(request, name, ""))
+	if err != nil || n < 1 {
+		return def
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// instructions is the user message that opens a prompt.
+func instructions(lines ...string) mcp.PromptMessage {
+	return mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(strings.Join(lines, "\n")))
+}
+
+// data formats live data as a user message titled title. When err is set,
+// the message says the data is unavailable and to call fallback instead.
+func data(title string, v interface{}, err error, fallback string) mcp.PromptMessage {
+	if err != nil {
+		return instructions(fmt.Sprintf("%s could not be fetched (%s). Call `%s` to get it.", title, strings.TrimSpace(err.Error()), fallback))
+	}
+	prettyJSON, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return instructions(fmt.Sprintf("%s could not be formatted (%v). Call `%s` to get it.", title, err, fallback))
+	}
+	return instructions(title + ":\n```json\n" + string(prettyJSON) + "\n```")
+}
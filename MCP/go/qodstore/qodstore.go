@@ -0,0 +1,175 @@
+package qodstore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+)
+
+// FileName is the registry file inside the data directory.
+const FileName = "qod_definitions.json"
+
+// The API has no endpoint listing private QOD definitions, so the id
+// returned by put_qod is only ever seen once. The registry keeps every
+// definition created or patched through this server in a JSON file,
+// scoped per account, so it can be looked up again by id or title.
+
+// Definition is a private QOD definition as last sent to put_qod or
+// patch_qod.
+type Definition struct {
+	ID          string    `json:"id,omitempty"`
+	Title       string    `json:"title"`
+	RepeatAfter int       `json:"repeat_after,omitempty"`
+	Authors     []string  `json:"authors,omitempty"`
+	Language    string    `json:"language,omitempty"`
+	SFW         bool      `json:"sfw"`
+	Private     bool      `json:"private"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Store reads and writes the registry file. Every Store shares one lock,
+// so concurrent tool calls in HTTP mode don't lose each other's updates.
+type Store struct {
+	path string
+}
+
+var mu sync.Mutex
+
+// DefaultDir is used when DATA_DIR is not set.
+func DefaultDir() string {
+	return config.DefaultDataDir()
+}
+
+// Open returns the registry in cfg.DataDir, or in DefaultDir.
+func Open(cfg *config.APIConfig) *Store {
+	dir := cfg.DataDir
+	if dir == "" {
+		dir = DefaultDir()
+	}
+	return &Store{path: filepath.Join(dir, FileName)}
+}
+
+// Path is the location of the registry file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+func (s *Store) load() (map[string][]Definition, error) {
+	data := map[string][]Definition{}
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// save writes through a temporary file so a crash never leaves a
+// truncated registry behind.
+func (s *Store) save(data map[string][]Definition) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// List returns the account's definitions, most recently updated first.
+func (s *Store) List(account string) ([]Definition, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	data, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	defs := append([]Definition{}, data[account]...)
+	sort.SliceStable(defs, func(i, j int) bool {
+		return defs[i].UpdatedAt.After(defs[j].UpdatedAt)
+	})
+	return defs, nil
+}
+
+// Find looks a definition up by id, then by title (case-insensitive).
+func (s *Store) Find(account, key string) (*Definition, error) {
+	defs, err := s.List(account)
+	if err != nil {
+		return nil, err
+	}
+	if i := find(defs, key, ""); i >= 0 {
+		return &defs[i], nil
+	}
+	if i := find(defs, "", key); i >= 0 {
+		return &defs[i], nil
+	}
+	return nil, nil
+}
+
+// find returns the index of the definition with the given id, or with the
+// given title when id is empty.
+func find(defs []Definition, id, title string) int {
+	for i, d := range defs {
+		if id != "" && d.ID == id {
+			return i
+		}
+		if id == "" && title != "" && strings.EqualFold(d.Title, title) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Update records a put_qod or patch_qod call. The definition is matched by
+// id when the API returned one, else by title, and created when neither
+// matches. apply changes the fields that were sent.
+func (s *Store) Update(account, id, title string, apply func(*Definition)) (Definition, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	data, err := s.load()
+	if err != nil {
+		return Definition{}, err
+	}
+	defs := data[account]
+	i := -1
+	if id != "" {
+		i = find(defs, id, "")
+	}
+	if i < 0 {
+		i = find(defs, "", title)
+	}
+	now := time.Now().UTC()
+	if i < 0 {
+		defs = append(defs, Definition{Title: title, CreatedAt: now})
+		i = len(defs) - 1
+	}
+	def := &defs[i]
+	if id != "" {
+		def.ID = id
+	}
+	apply(def)
+	def.UpdatedAt = now
+	data[account] = defs
+	if err := s.save(data); err != nil {
+		return Definition{}, err
+	}
+	return *def, nil
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"maps"
+	"slices"
+
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/toolsets"
 	tools_qshow "github.com/they-said-so-quotes-api/mcp-server/tools/qshow"
 	tools_quote "github.com/they-said-so-quotes-api/mcp-server/tools/quote"
 	tools_quote_images "github.com/they-said-so-quotes-api/mcp-server/tools/quote_images"
@@ -11,45 +16,178 @@ import (
 	tools_private_qod "github.com/they-said-so-quotes-api/mcp-server/tools/private_qod"
 )
 
-func GetAll(cfg *config.APIConfig) []models.Tool {
-	return []models.Tool{
-		tools_qshow.CreateGet_qshow_listTool(cfg),
-		tools_quote.CreateGet_quote_categories_popularTool(cfg),
-		tools_quote_images.CreateGet_quote_image_font_listTool(cfg),
-		tools_private_quotes.CreatePost_quote_tags_addTool(cfg),
-		tools_quote_of_the_day.CreateGet_qod_languagesTool(cfg),
-		tools_quote_images.CreateGet_quote_image_searchTool(cfg),
-		tools_quote.CreateGet_quote_authors_searchTool(cfg),
-		tools_private_quotes.CreateGet_quote_listTool(cfg),
-		tools_quote_images.CreatePost_quote_image_background_tags_removeTool(cfg),
-		tools_qshow.CreateGet_qshow_quotesTool(cfg),
-		tools_qshow.CreatePost_qshow_quotes_addTool(cfg),
-		tools_quote_images.CreatePost_quote_image_font_tags_removeTool(cfg),
-		tools_quote.CreateGet_quote_authors_popularTool(cfg),
-		tools_qshow.CreatePost_qshow_quotes_removeTool(cfg),
-		tools_quote_of_the_day.CreateGet_qod_categoriesTool(cfg),
-		tools_quote_images.CreatePost_quote_image_background_tags_addTool(cfg),
-		tools_quote_images.CreateGet_quote_imageTool(cfg),
-		tools_quote_images.CreatePut_quote_imageTool(cfg),
-		tools_quote_images.CreateGet_quote_image_background_searchTool(cfg),
-		tools_quote_images.CreateGet_quote_image_background_listTool(cfg),
-		tools_private_quotes.CreatePost_quote_tags_removeTool(cfg),
-		tools_quote.CreateGet_quote_bookmark_toggleTool(cfg),
-		tools_quote.CreateGet_quote_like_toggleTool(cfg),
-		tools_private_qod.CreatePut_qodTool(cfg),
-		tools_quote_of_the_day.CreateGet_qodTool(cfg),
-		tools_private_qod.CreatePatch_qodTool(cfg),
-		tools_quote.CreateGet_quote_categories_searchTool(cfg),
-		tools_quote_images.CreatePost_quote_image_font_tags_addTool(cfg),
-		tools_quote.CreateGet_quote_searchTool(cfg),
-		tools_quote_images.CreateGet_quote_image_font_searchTool(cfg),
-		tools_quote.CreateGet_quoteTool(cfg),
-		tools_private_quotes.CreatePatch_quoteTool(cfg),
-		tools_private_quotes.CreatePost_quoteTool(cfg),
-		tools_private_quotes.CreatePut_quoteTool(cfg),
-		tools_qshow.CreateGet_qshowTool(cfg),
-		tools_qshow.CreatePatch_qshowTool(cfg),
-		tools_qshow.CreatePut_qshowTool(cfg),
-		tools_quote.CreateGet_quote_randomTool(cfg),
+// toolsetCapabilities names the capability a whole toolset needs.
+var toolsetCapabilities = map[string]string{
+	toolsets.PrivateQuotes: capabilities.Private,
+	toolsets.PrivateQOD:    capabilities.PrivateQOD,
+}
+
+// toolCapabilities names the capability a tool needs, for tools of
+// toolsets that are otherwise usable without one.
+var toolCapabilities = map[string]string{
+	"get_quote_bookmark_toggle":               capabilities.Authenticated,
+	"get_quote_like_toggle":                   capabilities.Authenticated,
+	"put_qshow":                               capabilities.Private,
+	"patch_qshow":                             capabilities.Private,
+	"post_qshow_quotes_add":                   capabilities.Private,
+	"post_qshow_quotes_remove":                capabilities.Private,
+	"build_qshow":                             capabilities.Private,
+	"clone_qshow":                             capabilities.Private,
+	"merge_qshows":                            capabilities.Private,
+	"reorder_qshow":                           capabilities.Private,
+	"get_quote_image_background_list":         capabilities.Private,
+	"get_quote_image_font_list":               capabilities.Private,
+	"post_quote_image_background_tags_add":    capabilities.Private,
+	"post_quote_image_background_tags_remove": capabilities.Private,
+	"post_quote_image_font_tags_add":          capabilities.Private,
+	"post_quote_image_font_tags_remove":       capabilities.Private,
+	"batch_quote_image_background_tags":       capabilities.Private,
+	"batch_quote_image_font_tags":             capabilities.Private,
+}
+
+// argumentCapabilities names the capability tool arguments need. Without
+// it they are removed from the tool's input schema.
+var argumentCapabilities = map[string]map[string]string{
+	"get_quote_authors_search": {"detailed": capabilities.DetailedAuthors},
+	"get_author_profile":       {"detailed": capabilities.DetailedAuthors},
+	"put_quote_image":          {"branding": capabilities.Branding},
+	"create_quote_card":        {"branding": capabilities.Branding},
+}
+
+// GetAll returns the tools of the toolsets cfg enables that caps allow,
+// leaving out those that change data when cfg is read-only. A nil caps
+// allows every tool.
+func GetAll(cfg *config.APIConfig, caps capabilities.Set) []models.Tool {
+	byToolset := getToolsets(cfg)
+	var tools []models.Tool
+	for _, name := range enabledToolsets(cfg, caps) {
+		for _, tool := range byToolset[name] {
+			if cfg.ReadOnly && !readOnly(tool) {
+				continue
+			}
+			if capability, ok := toolCapabilities[tool.Definition.Name]; ok && !caps.Has(capability) {
+				continue
+			}
+			tools = append(tools, withArguments(tool, caps))
+		}
+	}
+	return tools
+}
+
+// enabledToolsets returns the toolsets cfg enables that caps allow, in
+// registration order.
+func enabledToolsets(cfg *config.APIConfig, caps capabilities.Set) []string {
+	enabled := cfg.Toolsets
+	if enabled == nil {
+		enabled = toolsets.All
+	}
+	var out []string
+	for _, name := range toolsets.All {
+		if !slices.Contains(enabled, name) {
+			continue
+		}
+		if capability, ok := toolsetCapabilities[name]; ok && !caps.Has(capability) {
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// readOnly reports whether a tool is annotated as not changing anything,
+// upstream or on disk.
+func readOnly(tool models.Tool) bool {
+	hint := tool.Definition.Annotations.ReadOnlyHint
+	return hint != nil && *hint
+}
+
+// withArguments removes the arguments of tool that caps does not allow.
+func withArguments(tool models.Tool, caps capabilities.Set) models.Tool {
+	schema := tool.Definition.InputSchema
+	for argument, capability := range argumentCapabilities[tool.Definition.Name] {
+		if caps.Has(capability) {
+			continue
+		}
+		properties := maps.Clone(schema.Properties)
+		delete(properties, argument)
+		schema.Properties = properties
+		schema.Required = slices.DeleteFunc(slices.Clone(schema.Required), func(name string) bool { return name == argument })
+	}
+	tool.Definition.InputSchema = schema
+	return tool
+}
+
+// getToolsets groups every tool by the toolset that enables it.
+func getToolsets(cfg *config.APIConfig) map[string][]models.Tool {
+	return map[string][]models.Tool{
+		toolsets.Quotes: {
+			tools_quote.CreateGet_quote_categories_popularTool(cfg),
+			tools_quote.CreateGet_quote_authors_searchTool(cfg),
+			tools_quote.CreateGet_quote_authors_popularTool(cfg),
+			tools_quote.CreateGet_quote_bookmark_toggleTool(cfg),
+			tools_quote.CreateGet_quote_like_toggleTool(cfg),
+			tools_quote.CreateGet_quote_categories_searchTool(cfg),
+			tools_quote.CreateGet_quote_searchTool(cfg),
+			tools_quote.CreateGet_quoteTool(cfg),
+			tools_quote.CreateGet_quote_randomTool(cfg),
+			tools_quote.CreateGet_author_profileTool(cfg),
+		},
+		toolsets.QOD: {
+			tools_quote_of_the_day.CreateGet_qod_languagesTool(cfg),
+			tools_quote_of_the_day.CreateGet_qod_categoriesTool(cfg),
+			tools_quote_of_the_day.CreateGet_qodTool(cfg),
+		},
+		toolsets.PrivateQuotes: {
+			tools_private_quotes.CreatePost_quote_tags_addTool(cfg),
+			tools_private_quotes.CreateGet_quote_listTool(cfg),
+			tools_private_quotes.CreatePost_quote_tags_removeTool(cfg),
+			tools_private_quotes.CreatePatch_quoteTool(cfg),
+			tools_private_quotes.CreatePost_quoteTool(cfg),
+			tools_private_quotes.CreatePut_quoteTool(cfg),
+			tools_private_quotes.CreateExport_quotesTool(cfg),
+			tools_private_quotes.CreateExport_quotes_fileTool(cfg),
+			tools_private_quotes.CreateBatch_quote_tagsTool(cfg),
+			tools_private_quotes.CreateFind_duplicate_quotesTool(cfg),
+			tools_private_quotes.CreateSync_collectionTool(cfg),
+			tools_private_quotes.CreateBackup_accountTool(cfg),
+			tools_private_quotes.CreateRestore_accountTool(cfg),
+			tools_private_quotes.CreateMigrate_accountTool(cfg),
+		},
+		toolsets.PrivateQOD: {
+			tools_private_qod.CreatePut_qodTool(cfg),
+			tools_private_qod.CreatePatch_qodTool(cfg),
+			tools_private_qod.CreatePreview_qod_definitionTool(cfg),
+			tools_private_qod.CreateList_qod_definitionsTool(cfg),
+			tools_private_qod.CreateGet_qod_definitionTool(cfg),
+		},
+		toolsets.Qshow: {
+			tools_qshow.CreateGet_qshow_listTool(cfg),
+			tools_qshow.CreateGet_qshow_quotesTool(cfg),
+			tools_qshow.CreatePost_qshow_quotes_addTool(cfg),
+			tools_qshow.CreatePost_qshow_quotes_removeTool(cfg),
+			tools_qshow.CreateGet_qshowTool(cfg),
+			tools_qshow.CreatePatch_qshowTool(cfg),
+			tools_qshow.CreatePut_qshowTool(cfg),
+			tools_qshow.CreateBuild_qshowTool(cfg),
+			tools_qshow.CreateClone_qshowTool(cfg),
+			tools_qshow.CreateMerge_qshowsTool(cfg),
+			tools_qshow.CreateReorder_qshowTool(cfg),
+		},
+		toolsets.Images: {
+			tools_quote_images.CreateGet_quote_image_font_listTool(cfg),
+			tools_quote_images.CreateGet_quote_image_searchTool(cfg),
+			tools_quote_images.CreatePost_quote_image_background_tags_removeTool(cfg),
+			tools_quote_images.CreatePost_quote_image_font_tags_removeTool(cfg),
+			tools_quote_images.CreatePost_quote_image_background_tags_addTool(cfg),
+			tools_quote_images.CreateGet_quote_imageTool(cfg),
+			tools_quote_images.CreatePut_quote_imageTool(cfg),
+			tools_quote_images.CreateGet_quote_image_background_searchTool(cfg),
+			tools_quote_images.CreateGet_quote_image_background_listTool(cfg),
+			tools_quote_images.CreatePost_quote_image_font_tags_addTool(cfg),
+			tools_quote_images.CreateGet_quote_image_font_searchTool(cfg),
+			tools_quote_images.CreateBatch_quote_image_background_tagsTool(cfg),
+			tools_quote_images.CreateBatch_quote_image_font_tagsTool(cfg),
+			tools_quote_images.CreateCreate_quote_cardTool(cfg),
+		},
 	}
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/toolsets"
+)
+
+func TestNarrowedHeaders(t *testing.T) {
+	env := &config.APIConfig{Toolsets: toolsets.All}
+	r := httptest.NewRequest("POST", "/mcp", nil)
+	r.Header.Set("API_BASE_URL", "https://api.example.com")
+	r.Header.Set("TOOLSETS", "quotes")
+	r.Header.Set("READ_ONLY", "true")
+	cfg, err := headerConfig(env, r)
+	if err != nil {
+		t.Fatalf("headerConfig() error = %v", err)
+	}
+	v := newView(cfg, nil)
+
+	var tools []string
+	for _, tool := range v.tools {
+		tools = append(tools, tool.Definition.Name)
+		if !readOnly(tool) {
+			t.Errorf("tool %s changes data but READ_ONLY is set", tool.Definition.Name)
+		}
+	}
+	for _, name := range []string{"get_quote", "get_quote_search", "get_author_profile"} {
+		if !slices.Contains(tools, name) {
+			t.Errorf("tool %s of the quotes toolset is missing: %v", name, tools)
+		}
+	}
+	for _, name := range []string{"get_qod", "put_quote", "build_qshow", "create_quote_card"} {
+		if slices.Contains(tools, name) {
+			t.Errorf("tool %s is exposed outside the narrowed toolsets", name)
+		}
+	}
+	if len(v.prompts) != 0 {
+		t.Errorf("prompts = %v, want none: each needs a tool the headers hide", promptNames(v))
+	}
+	var templates []string
+	for _, template := range v.templates {
+		templates = append(templates, template.Definition.URITemplate.Raw())
+	}
+	if want := []string{"quote://{id}", "author://{name}"}; !reflect.DeepEqual(templates, want) {
+		t.Errorf("templates = %v, want %v", templates, want)
+	}
+}
+
+func TestHeadersCannotWiden(t *testing.T) {
+	env := &config.APIConfig{Toolsets: []string{toolsets.Quotes}, ReadOnly: true}
+	r := httptest.NewRequest("POST", "/mcp", nil)
+	r.Header.Set("API_BASE_URL", "https://api.example.com")
+	r.Header.Set("READ_ONLY", "false")
+	cfg, err := headerConfig(env, r)
+	if err != nil {
+		t.Fatalf("headerConfig() error = %v", err)
+	}
+	if !cfg.ReadOnly || !reflect.DeepEqual(cfg.Toolsets, env.Toolsets) {
+		t.Errorf("headerConfig() = toolsets %v, read-only %v; want those of the environment", cfg.Toolsets, cfg.ReadOnly)
+	}
+
+	r.Header.Set("TOOLSETS", "quotes,qod")
+	cfg, err = headerConfig(env, r)
+	if err != nil {
+		t.Fatalf("headerConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Toolsets, env.Toolsets) {
+		t.Errorf("headerConfig() toolsets = %v, want %v", cfg.Toolsets, env.Toolsets)
+	}
+}
+
+func TestPromptTools(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolsets []string
+		readOnly bool
+		caps     capabilities.Set
+		want     []string
+	}{
+		{
+			name: "everything",
+			want: []string{"daily_inspiration", "build_qshow", "brand_quote_card", "curate_author"},
+		},
+		{
+			name:     "read-only",
+			readOnly: true,
+			want:     nil,
+		},
+		{
+			name:     "without qod",
+			toolsets: []string{toolsets.Quotes, toolsets.PrivateQuotes, toolsets.Qshow, toolsets.Images},
+			want:     []string{"build_qshow", "brand_quote_card", "curate_author"},
+		},
+		{
+			name:     "without private quotes",
+			toolsets: []string{toolsets.Quotes, toolsets.QOD, toolsets.Qshow, toolsets.Images},
+			want:     []string{"daily_inspiration", "build_qshow", "brand_quote_card"},
+		},
+		{
+			name:     "qod and images",
+			toolsets: []string{toolsets.QOD, toolsets.Images},
+			want:     []string{"daily_inspiration", "brand_quote_card"},
+		},
+		{
+			name: "without the private capability",
+			caps: capabilities.Set{capabilities.Authenticated: true},
+			want: []string{"daily_inspiration"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newView(&config.APIConfig{Toolsets: tt.toolsets, ReadOnly: tt.readOnly}, tt.caps)
+			if got := promptNames(v); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("prompts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func promptNames(v *view) []string {
+	var names []string
+	for _, prompt := range v.prompts {
+		names = append(names, prompt.Definition.Name)
+	}
+	return names
+}
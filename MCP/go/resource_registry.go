@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/resources"
+	"github.com/they-said-so-quotes-api/mcp-server/toolsets"
+)
+
+// GetAllResourceTemplates returns the resource templates of the toolsets
+// cfg enables that caps allow, as GetAll does for tools, so resources do
+// not expose what the tools of a disabled toolset would. Templates only
+// read, so read-only mode keeps them all.
+func GetAllResourceTemplates(cfg *config.APIConfig, caps capabilities.Set) []models.ResourceTemplate {
+	byToolset := getResourceTemplates(cfg)
+	var templates []models.ResourceTemplate
+	for _, name := range enabledToolsets(cfg, caps) {
+		templates = append(templates, byToolset[name]...)
+	}
+	return templates
+}
+
+func getResourceTemplates(cfg *config.APIConfig) map[string][]models.ResourceTemplate {
+	return map[string][]models.ResourceTemplate{
+		toolsets.Quotes: {
+			resources.CreateQuoteTemplate(cfg),
+			resources.CreateAuthorTemplate(cfg),
+		},
+		toolsets.QOD: {
+			resources.CreateQodTemplate(cfg),
+		},
+		toolsets.PrivateQOD: {
+			resources.CreateQodIdTemplate(cfg),
+		},
+		toolsets.Qshow: {
+			resources.CreateQshowTemplate(cfg),
+			resources.CreateQshowQuotesTemplate(cfg),
+		},
+		toolsets.Images: {
+			resources.CreateImageTemplate(cfg),
+		},
+	}
+}
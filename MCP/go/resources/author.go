@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// AuthorQuoteLimit is how many quotes an author resource includes.
+const AuthorQuoteLimit = 10
+
+func AuthorHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		name := templateArg(request, "name")
+		if name == "" {
+			return nil, fmt.Errorf("missing author name in %s", request.Params.URI)
+		}
+		c := client.New(cfg)
+		warnings := []string{}
+
+		// Author details need a paid tier; the quotes are still useful
+		// without them.
+		var author *models.Author
+		authors, err := c.SearchAuthors(ctx, name, "", true, 5)
+		switch {
+		case client.IsTierError(err):
+			warnings = append(warnings, "Author details are not available on this subscription tier")
+		case err != nil:
+			return nil, fmt.Errorf("searching for author %s: %w", name, err)
+		}
+		for i := range authors {
+			if strings.EqualFold(authors[i].Name, name) {
+				author = &authors[i]
+				break
+			}
+		}
+		if author == nil && len(authors) > 0 {
+			author = &authors[0]
+		}
+		if author != nil {
+			name = author.Name
+		}
+
+		quotes, err := c.SearchQuotes(ctx, url.Values{"author": {name}, "limit": {fmt.Sprint(AuthorQuoteLimit)}})
+		if err != nil && !client.IsNotFound(err) {
+			warnings = append(warnings, fmt.Sprintf("Could not fetch quotes: %v", err))
+		}
+		if quotes == nil {
+			quotes = []models.Quote{}
+		}
+		return jsonContents(request.Params.URI, map[string]interface{}{
+			"name":     name,
+			"author":   author,
+			"quotes":   quotes,
+			"warnings": warnings,
+		})
+	}
+}
+
+func CreateAuthorTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("author://{name}", "Author",
+		mcp.WithTemplateDescription("An author's details, when the subscription tier allows, and some of their quotes. For more, use `get_author_profile`."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    AuthorHandler(cfg),
+	}
+}
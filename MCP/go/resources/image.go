@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func ImageHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		id := templateArg(request, "id")
+		data, err := client.New(cfg).QuoteImageData(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching quote image %s: %w", id, err)
+		}
+		return []mcp.ResourceContents{mcp.BlobResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: http.DetectContentType(data),
+			Blob:     base64.StdEncoding.EncodeToString(data),
+		}}, nil
+	}
+}
+
+func CreateImageTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("image://{id}", "Quote image",
+		mcp.WithTemplateDescription("The rendered file of a quote image, as created by `put_quote_image` or `create_quote_card`."),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    ImageHandler(cfg),
+	}
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// ParseQOD splits a qod:// URI into the category and language of a public
+// QOD, or the definition id of a private one (qod://id/{id}). Both forms
+// match the qod://{category}/{language} template, so "id" is not a
+// category.
+func ParseQOD(uri string) (category, language, id string, ok bool) {
+	rest, found := strings.CutPrefix(uri, "qod://")
+	if !found {
+		return "", "", "", false
+	}
+	first, second, found := strings.Cut(rest, "/")
+	if !found || first == "" || second == "" || strings.Contains(second, "/") {
+		return "", "", "", false
+	}
+	if first == "id" {
+		return "", "", second, true
+	}
+	return first, second, "", true
+}
+
+func QodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		category, language, id, ok := ParseQOD(request.Params.URI)
+		if !ok {
+			return nil, fmt.Errorf("not a QOD resource: %s", request.Params.URI)
+		}
+		qod, err := client.New(cfg).QOD(ctx, category, language, id)
+		switch {
+		case err != nil && id != "":
+			return nil, fmt.Errorf("fetching the quote of the day of definition %s: %w", id, err)
+		case err != nil:
+			return nil, fmt.Errorf("fetching the %s quote of the day in %s: %w", category, language, err)
+		}
+		return jsonContents(request.Params.URI, qod)
+	}
+}
+
+func CreateQodTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("qod://{category}/{language}", "Quote of the Day",
+		mcp.WithTemplateDescription("Today's quote of the day for a category (see `get_qod_categories`) in a language (see `get_qod_languages`), e.g. qod://inspire/en. Subscribe to be notified when the day rolls over."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    QodHandler(cfg),
+	}
+}
+
+func CreateQodIdTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("qod://id/{id}", "Private Quote of the Day",
+		mcp.WithTemplateDescription("Today's quote of a private QOD definition (see `list_qod_definitions`). Subscribe to be notified when the day rolls over."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    QodHandler(cfg),
+	}
+}
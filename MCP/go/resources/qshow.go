@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func QshowHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		id := templateArg(request, "id")
+		qshow, quotes, err := client.New(cfg).QshowQuotes(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching qshow %s: %w", id, err)
+		}
+		if qshow.Id == "" {
+			qshow.Id = id
+		}
+		return jsonContents(request.Params.URI, map[string]interface{}{
+			"qshow":       qshow,
+			"quote_count": len(quotes),
+		})
+	}
+}
+
+func CreateQshowTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("qshow://{id}", "Qshow",
+		mcp.WithTemplateDescription("A qshow's title, description, tags and number of quotes. Its quotes are at qshow://{id}/quotes."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    QshowHandler(cfg),
+	}
+}
+
+func QshowQuotesHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		id := templateArg(request, "id")
+		_, quotes, err := client.New(cfg).QshowQuotes(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching the quotes of qshow %s: %w", id, err)
+		}
+		if quotes == nil {
+			quotes = []models.Quote{}
+		}
+		return jsonContents(request.Params.URI, quotes)
+	}
+}
+
+func CreateQshowQuotesTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("qshow://{id}/quotes", "Qshow quotes",
+		mcp.WithTemplateDescription("The quotes of a qshow, in order."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    QshowQuotesHandler(cfg),
+	}
+}
@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func QuoteHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		id := templateArg(request, "id")
+		if id == "" {
+			return nil, fmt.Errorf("missing quote id in %s", request.Params.URI)
+		}
+		quote, err := client.New(cfg).GetQuote(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching quote %s: %w", id, err)
+		}
+		return jsonContents(request.Params.URI, quote)
+	}
+}
+
+func CreateQuoteTemplate(cfg *config.APIConfig) models.ResourceTemplate {
+	template := mcp.NewResourceTemplate("quote://{id}", "Quote",
+		mcp.WithTemplateDescription("A public or private quote by id, with its author, tags and language."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return models.ResourceTemplate{
+		Definition: template,
+		Handler:    QuoteHandler(cfg),
+	}
+}
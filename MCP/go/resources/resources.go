@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Resource templates expose the API as readable MCP resources, so clients
+// can attach quotes, qshows and authors as context without a tool call.
+// They are backed by the same client, and so the same cache, as the tools.
+
+// templateArg returns a variable matched from the resource URI. The server
+// passes matched variables as string lists.
+func templateArg(request mcp.ReadResourceRequest, name string) string {
+	var value string
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		if len(v) > 0 {
+			value = v[0]
+		}
+	}
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// jsonContents returns v as the pretty printed JSON contents of uri.
+func jsonContents(uri string, v interface{}) ([]mcp.ResourceContents, error) {
+	prettyJSON, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      uri,
+		MIMEType: "application/json",
+		Text:     string(prettyJSON),
+	}}, nil
+}
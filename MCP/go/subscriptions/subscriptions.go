@@ -0,0 +1,181 @@
+package subscriptions
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/resources"
+)
+
+// DefaultPollInterval is how often subscribed QOD resources are fetched to
+// detect the upstream day rollover.
+const DefaultPollInterval = 5 * time.Minute
+
+// Hub tracks the resource subscriptions of every session of a server.
+type Hub struct {
+	mu       sync.Mutex
+	srv      *server.MCPServer
+	sessions map[string]*session
+	// last is the date and id of the last seen QOD per account and URI.
+	last map[string]string
+}
+
+type session struct {
+	cfg  *config.APIConfig
+	uris map[string]bool
+}
+
+func NewHub() *Hub {
+	return &Hub{sessions: map[string]*session{}, last: map[string]string{}}
+}
+
+// Attach makes s record the subscriptions its sessions make, using the
+// configuration of the subscribing request for the upstream calls, and
+// send the notifications. Only registered sessions, which s can notify,
+// are tracked. hooks must be the hooks s was created with.
+func (h *Hub) Attach(s *server.MCPServer, hooks *server.Hooks) {
+	h.mu.Lock()
+	h.srv = s
+	h.mu.Unlock()
+	hooks.AddOnRegisterSession(func(ctx context.Context, cs server.ClientSession) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.sessions[cs.SessionID()] = &session{uris: map[string]bool{}}
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, cs server.ClientSession) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.sessions, cs.SessionID())
+	})
+	hooks.AddAfterSubscribe(func(ctx context.Context, id any, message *mcp.SubscribeRequest, result *mcp.EmptyResult) {
+		cs := server.ClientSessionFromContext(ctx)
+		cfg := config.FromContext(ctx)
+		if cs == nil || cfg == nil {
+			return
+		}
+		uri := message.Params.URI
+		h.mu.Lock()
+		sess, ok := h.sessions[cs.SessionID()]
+		if ok {
+			sess.cfg = cfg
+			sess.uris[uri] = true
+		}
+		h.mu.Unlock()
+		if !ok {
+			return
+		}
+		// The QOD the client is looking at now is the baseline for the
+		// next rollover.
+		go h.check(context.Background(), cfg, uri, false)
+	})
+	hooks.AddAfterUnsubscribe(func(ctx context.Context, id any, message *mcp.UnsubscribeRequest, result *mcp.EmptyResult) {
+		cs := server.ClientSessionFromContext(ctx)
+		if cs == nil {
+			return
+		}
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if sess, ok := h.sessions[cs.SessionID()]; ok {
+			delete(sess.uris, message.Params.URI)
+		}
+	})
+}
+
+// Run checks the subscribed resources every interval until ctx is done.
+func (h *Hub) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.Poll(ctx)
+		}
+	}
+}
+
+// Poll fetches every subscribed QOD resource once per account and sends
+// notifications/resources/updated to the subscribers of those whose quote
+// changed since the last check. The last seen QODs no session subscribes
+// to anymore are dropped.
+func (h *Hub) Poll(ctx context.Context) {
+	type target struct {
+		cfg *config.APIConfig
+		uri string
+	}
+	targets := map[string]target{}
+	h.mu.Lock()
+	for _, sess := range h.sessions {
+		for uri := range sess.uris {
+			targets[client.New(sess.cfg).Account()+" "+uri] = target{sess.cfg, uri}
+		}
+	}
+	for key := range h.last {
+		if _, ok := targets[key]; !ok {
+			delete(h.last, key)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, t := range targets {
+		h.check(ctx, t.cfg, t.uri, true)
+	}
+}
+
+// check fetches the QOD behind uri and records it. When notify is set
+// and it differs from the one recorded before, the subscribers are
+// notified.
+func (h *Hub) check(ctx context.Context, cfg *config.APIConfig, uri string, notify bool) {
+	category, language, id, ok := resources.ParseQOD(uri)
+	if !ok {
+		// Only QOD resources change on their own
+		return
+	}
+	c := client.New(cfg)
+	qod, err := c.RefreshQOD(ctx, category, language, id)
+	if err != nil {
+		log.Printf("Checking %s for subscribers: %v", uri, err)
+		return
+	}
+	key := c.Account() + " " + uri
+	seen := qod.Date + "\x00" + qod.Id
+
+	h.mu.Lock()
+	previous, known := h.last[key]
+	h.last[key] = seen
+	h.mu.Unlock()
+	if notify && known && previous != seen {
+		h.notify(c.Account(), uri)
+	}
+}
+
+// notify sends notifications/resources/updated for uri to the sessions of
+// account subscribed to it.
+func (h *Hub) notify(account, uri string) {
+	params := map[string]any{"uri": uri}
+	h.mu.Lock()
+	srv := h.srv
+	var targets []string
+	for id, sess := range h.sessions {
+		if sess.uris[uri] && client.New(sess.cfg).Account() == account {
+			targets = append(targets, id)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, id := range targets {
+		if err := srv.SendNotificationToSpecificClient(id, mcp.MethodNotificationResourceUpdated, params); err != nil {
+			log.Printf("Notifying a subscriber of %s: %v", uri, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// confirmPatch asks to confirm an update of a QOD definition, showing each
+// filter that changes. The previous values come from the local registry;
+// for a definition it does not know, every filter given is shown as set.
+func confirmPatch(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest, args map[string]any) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, false, func() (confirm.Change, error) {
+		title := request.GetString("title", "")
+		def, err := qodstore.Open(cfg).Find(client.New(cfg).Account(), title)
+		if err != nil {
+			return confirm.Change{}, err
+		}
+		if def == nil {
+			def = &qodstore.Definition{}
+		}
+		var fields []string
+		for _, f := range []struct{ name, old string }{
+			{"repeat_after", strconv.Itoa(def.RepeatAfter)},
+			{"authors", strings.Join(def.Authors, ",")},
+			{"language", def.Language},
+			{"sfw", strconv.FormatBool(def.SFW)},
+			{"private", strconv.FormatBool(def.Private)},
+		} {
+			v, ok := args[f.name]
+			if !ok {
+				continue
+			}
+			value := client.String(v)
+			if f.name == "authors" {
+				value = strings.Join(client.Strings(v), ",")
+			}
+			if def.Title == "" {
+				fields = append(fields, confirm.Field(f.name, "", value))
+			} else if value != f.old {
+				fields = append(fields, confirm.Field(f.name, f.old, value))
+			}
+		}
+		if len(fields) == 0 {
+			return confirm.Change{}, nil
+		}
+		return confirm.Change{Summary: fmt.Sprintf("Update QOD definition %q", title), Details: fields}, nil
+	})
+}
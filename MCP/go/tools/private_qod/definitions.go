@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/logging"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// recordQodDefinition saves a successful put_qod or patch_qod call to the
+// local registry. Only the filters present in args are changed, matching
+// the PATCH semantics of the API. A failure to record is logged rather
+// than failing a call that already succeeded upstream.
+func recordQodDefinition(ctx context.Context, cfg *config.APIConfig, args map[string]any, body []byte) {
+	var result map[string]interface{}
+	_ = json.Unmarshal(body, &result)
+	title := client.String(args["title"])
+	c := client.New(cfg)
+	_, err := qodstore.Open(cfg).Update(c.Account(), client.ID(result), title, func(d *qodstore.Definition) {
+		d.Title = title
+		if v, ok := args["repeat_after"]; ok {
+			d.RepeatAfter = client.Int(v)
+		}
+		if v, ok := args["authors"]; ok {
+			d.Authors = client.Strings(v)
+		}
+		if v, ok := args["language"]; ok {
+			d.Language = client.String(v)
+		}
+		if v, ok := args["sfw"]; ok {
+			d.SFW = argBool(v)
+		}
+		if v, ok := args["private"]; ok {
+			d.Private = argBool(v)
+		}
+	})
+	if err != nil {
+		logging.Warningf(ctx, logging.LoggerTool, "Failed to record QOD definition %q: %v", title, err)
+	}
+}
+
+// argBool reads a boolean argument sent either as a JSON boolean or, from
+// the command line, as a string.
+func argBool(v interface{}) bool {
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	b, _ := strconv.ParseBool(client.String(v))
+	return b
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+func Get_qod_definitionHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := request.Params.Arguments.(map[string]any); !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		key := request.GetString("id", "")
+		if key == "" {
+			key = request.GetString("title", "")
+		}
+		if key == "" {
+			return mcp.NewToolResultError("Either `id` or `title` is required"), nil
+		}
+		def, err := qodstore.Open(cfg).Find(client.New(cfg).Account(), key)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to read QOD definition registry", err), nil
+		}
+		if def == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("No QOD definition %q in the local registry. Use `list_qod_definitions` to see the known definitions.", key)), nil
+		}
+
+		return models.ToolResult(def), nil
+	}
+}
+
+func CreateGet_qod_definitionTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("get_qod_definition",
+		mcp.WithToolTitle("Get QOD Definition"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithDescription("Get a private `Quote of the Day` definition from the local registry by id or title: its filters and when it was created and last updated through this server."),
+		mcp.WithString("id", mcp.Description("QOD definition id")),
+		mcp.WithString("title", mcp.Description("Title of the Quote of the day category. Used when `id` is not given.")),
+		mcp.WithOutputSchema[qodstore.Definition](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Get_qod_definitionHandler(cfg),
+	}
+}
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
+)
+
+// QODDefinitionList is the result of list_qod_definitions.
+type QODDefinitionList struct {
+	Total       int                   `json:"total"`
+	Definitions []qodstore.Definition `json:"definitions"`
+}
+
+func List_qod_definitionsHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		defs, err := qodstore.Open(cfg).List(client.New(cfg).Account())
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to read QOD definition registry", err), nil
+		}
+
+		return models.ToolResult(QODDefinitionList{Total: len(defs), Definitions: defs}), nil
+	}
+}
+
+func CreateList_qod_definitionsTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("list_qod_definitions",
+		mcp.WithToolTitle("List QOD Definitions"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+		mcp.WithDescription("List the private `Quote of the Day` definitions created or updated through this server with `put_qod` and `patch_qod`, most recently updated first. The API has no such listing; definitions made elsewhere are not included."),
+		mcp.WithOutputSchema[QODDefinitionList](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    List_qod_definitionsHandler(cfg),
+	}
+}
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -19,6 +19,9 @@ func Patch_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
+		if stop := confirmPatch(ctx, cfg, request, args); stop != nil {
+			return stop, nil
+		}
 		queryParams := make([]string, 0)
 		if val, ok := args["repeat_after"]; ok {
 			queryParams = append(queryParams, fmt.Sprintf("repeat_after=%v", val))
@@ -43,7 +46,7 @@ func Patch_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qod%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PATCH", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -53,7 +56,7 @@ func Patch_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -67,24 +70,24 @@ func Patch_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result models.QuoteResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		recordQodDefinition(ctx, cfg, args, body)
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePatch_qodTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_qod",
+		mcp.WithToolTitle("Update QOD Definition"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Update an existing private `Quote of the Day` definition.
 "),
 		mcp.WithNumber("repeat_after", mcp.Description("How many days after the quotes can repeat? If you are setting this up from your private collection make sure you have more quotes that meet the filter conditions than the days you specify here.")),
@@ -93,6 +96,7 @@ func CreatePatch_qodTool(cfg *config.APIConfig) models.Tool {
 		mcp.WithBoolean("private", mcp.Description("Should apply the filters to the private collection. Default is public quotes in the platform.")),
 		mcp.WithString("language", mcp.Description("Quotes language.")),
 		mcp.WithBoolean("sfw", mcp.Description("Consider only quotes marked as \"sfw\" (Safe for work).")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+const (
+	// DefaultRepeatAfter mirrors the API default for repeat_after.
+	DefaultRepeatAfter = 30
+	// MaxPreviewSearchLimit caps how many candidates a single search asks
+	// for. Subscriptions may cap it lower still.
+	MaxPreviewSearchLimit = 500
+	// DefaultPreviewDays is how many upcoming days are sampled.
+	DefaultPreviewDays = 7
+	MaxPreviewDays     = 31
+)
+
+// qodFilters are the filter arguments shared by put_qod and patch_qod.
+type qodFilters struct {
+	Title       string   `json:"title,omitempty"`
+	RepeatAfter int      `json:"repeat_after"`
+	Authors     []string `json:"authors,omitempty"`
+	Language    string   `json:"language"`
+	SFW         bool     `json:"sfw"`
+	Private     bool     `json:"private"`
+}
+
+// QODPreview is the result of preview_qod_definition.
+type QODPreview struct {
+	Filters    qodFilters `json:"filters"`
+	Candidates int        `json:"candidates"`
+	// CandidatesAtLeast is set when a search hit its limit, so more quotes
+	// may match than Candidates.
+	CandidatesAtLeast bool             `json:"candidates_at_least"`
+	Sufficient        bool             `json:"sufficient"`
+	Warnings          []string         `json:"warnings"`
+	Sample            []ScheduledQuote `json:"sample"`
+}
+
+// ScheduledQuote is a quote a definition could serve on a date.
+type ScheduledQuote struct {
+	Date   string `json:"date"`
+	Id     string `json:"id"`
+	Quote  string `json:"quote"`
+	Author string `json:"author,omitempty"`
+}
+
+// qodCandidates counts the quotes matching a QOD definition's filters.
+// atLeast reports that a search hit its limit, so more quotes may match
+// than were counted.
+func qodCandidates(ctx context.Context, c *client.Client, f qodFilters) (candidates []models.Quote, atLeast bool, err error) {
+	limit := f.RepeatAfter + 1
+	if limit > MaxPreviewSearchLimit {
+		limit = MaxPreviewSearchLimit
+	}
+	seen := map[string]bool{}
+	add := func(q models.Quote) {
+		if q.Id != "" && !seen[q.Id] {
+			seen[q.Id] = true
+			candidates = append(candidates, q)
+		}
+	}
+
+	authors := f.Authors
+	if len(authors) == 0 {
+		authors = []string{""}
+	}
+	for _, author := range authors {
+		params := url.Values{"language": {f.Language}, "limit": {strconv.Itoa(limit)}}
+		if author != "" {
+			params.Set("author", author)
+		}
+		if f.SFW {
+			params.Set("sfw", "true")
+		}
+		if f.Private {
+			params.Set("private", "true")
+		}
+		quotes, err := c.SearchQuotes(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(quotes) >= limit {
+			atLeast = true
+		}
+		for _, q := range quotes {
+			add(q)
+		}
+	}
+
+	// Search results are capped by the subscription, so for the private
+	// collection the full listing is scanned as well. The listing has no
+	// sfw flag, so it is only used when sfw filtering is off.
+	if f.Private && !f.SFW {
+		quotes, err := c.CachedPrivateQuotes(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		wanted := map[string]bool{}
+		for _, a := range f.Authors {
+			wanted[dedupe.Normalize(a)] = true
+		}
+		for _, q := range quotes {
+			lang := q.Language
+			if lang == "" {
+				lang = "en"
+			}
+			if !strings.EqualFold(lang, f.Language) {
+				continue
+			}
+			if len(wanted) > 0 && !wanted[dedupe.Normalize(q.Author)] {
+				continue
+			}
+			add(q)
+		}
+		atLeast = false
+	}
+	return candidates, atLeast, nil
+}
+
+// sampleSchedule picks a plausible quote for each of the next days. The
+// platform chooses randomly; the sample is seeded from the title so
+// previews of the same definition are stable.
+func sampleSchedule(candidates []models.Quote, title string, days int, start time.Time) []ScheduledQuote {
+	h := fnv.New64a()
+	h.Write([]byte(title))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+	order := rng.Perm(len(candidates))
+
+	sample := []ScheduledQuote{}
+	for i := 0; i < days && len(candidates) > 0; i++ {
+		q := candidates[order[i%len(order)]]
+		sample = append(sample, ScheduledQuote{
+			Date:   start.AddDate(0, 0, i).Format("2006-01-02"),
+			Id:     q.Id,
+			Quote:  q.Quote,
+			Author: q.Author,
+		})
+	}
+	return sample
+}
+
+func Preview_qod_definitionHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := request.Params.Arguments.(map[string]any); !ok && request.Params.Arguments != nil {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		f := qodFilters{
+			Title:       request.GetString("title", ""),
+			RepeatAfter: request.GetInt("repeat_after", DefaultRepeatAfter),
+			Authors:     client.SplitList(request.GetString("authors", "")),
+			Language:    request.GetString("language", "en"),
+			SFW:         request.GetBool("sfw", false),
+			Private:     request.GetBool("private", false),
+		}
+		days := request.GetInt("days", DefaultPreviewDays)
+		if days < 1 {
+			days = 1
+		} else if days > MaxPreviewDays {
+			days = MaxPreviewDays
+		}
+
+		candidates, atLeast, err := qodCandidates(ctx, client.New(cfg), f)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to count matching quotes", err), nil
+		}
+
+		warnings := []string{}
+		sufficient := len(candidates) > f.RepeatAfter || atLeast
+		if len(candidates) == 0 {
+			warnings = append(warnings, "No quotes match these filters; the definition would have nothing to serve.")
+		} else if !sufficient {
+			warnings = append(warnings, fmt.Sprintf("Only %d quotes match but repeat_after is %d. Add quotes, widen the filters or lower repeat_after to at most %d.", len(candidates), f.RepeatAfter, len(candidates)-1))
+		}
+		if f.Private && f.SFW {
+			warnings = append(warnings, "The private collection was counted through search only, which is capped by your subscription.")
+		}
+
+		return models.ToolResult(QODPreview{
+			Filters:           f,
+			Candidates:        len(candidates),
+			CandidatesAtLeast: atLeast,
+			Sufficient:        sufficient,
+			Warnings:          warnings,
+			Sample:            sampleSchedule(candidates, f.Title, days, time.Now().UTC().AddDate(0, 0, 1)),
+		}), nil
+	}
+}
+
+func CreatePreview_qod_definitionTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("preview_qod_definition",
+		mcp.WithToolTitle("Preview QOD Definition"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Check a private `Quote of the Day` definition before saving it with `put_qod` or `patch_qod`. Counts the quotes matching the filters, warns when fewer than `repeat_after` match and shows a sample of what the coming days could look like."),
+		mcp.WithNumber("repeat_after", mcp.Description("How many days after the quotes can repeat? Defaults to 30.")),
+		mcp.WithString("authors", mcp.Description("Comma seperated author names. Quotes will be chosen from one of these authors.")),
+		mcp.WithString("title", mcp.Description("Title of the Quote of the day category. Only used to seed the sample.")),
+		mcp.WithBoolean("private", mcp.Description("Should apply the filters to the private collection. Default is public quotes in the platform.")),
+		mcp.WithString("language", mcp.Description("Quotes language. Defaults to en.")),
+		mcp.WithBoolean("sfw", mcp.Description("Consider only quotes marked as \"sfw\" (Safe for work).")),
+		mcp.WithNumber("days", mcp.Description("How many upcoming days to sample. Defaults to 7, at most 31.")),
+		mcp.WithOutputSchema[QODPreview](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Preview_qod_definitionHandler(cfg),
+	}
+}
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -43,7 +43,7 @@ func Put_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qod%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PUT", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -53,7 +53,7 @@ func Put_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -67,24 +67,24 @@ func Put_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result models.SuccessResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		recordQodDefinition(ctx, cfg, args, body)
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePut_qodTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("put_qod",
+		mcp.WithToolTitle("Create QOD Definition"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Create a private `Quote of the Day` service. 
 "),
 		mcp.WithNumber("repeat_after", mcp.Description("How many days after the quotes can repeat? If you are setting this up from your private collection make sure you have more quotes that meet the filter conditions than the days you specify here.")),
@@ -93,6 +93,7 @@ func CreatePut_qodTool(cfg *config.APIConfig) models.Tool {
 		mcp.WithBoolean("private", mcp.Description("Should apply the filters to the private collection. Default is public quotes in the platform.")),
 		mcp.WithString("language", mcp.Description("Quotes language.")),
 		mcp.WithBoolean("sfw", mcp.Description("Consider only quotes marked as \"sfw\" (Safe for work).")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
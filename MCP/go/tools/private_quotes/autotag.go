@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/autotag"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+)
+
+// autoTagOption is the `auto_tag` argument shared by put_quote, post_quote
+// and patch_quote.
+var autoTagOption = mcp.WithBoolean("auto_tag",
+	mcp.Description("When `tags` is not given, choose up to 3 tags among the existing quote categories, and the language when `language` is not given. The client's model is asked through MCP sampling when the client supports it; otherwise a local heuristic matches categories against the words of the quote. In HTTP mode sampling is not available, so only the heuristic is used. What was chosen is reported under `auto_tag`."),
+)
+
+// QuoteUpdated is the result of patch_quote.
+type QuoteUpdated struct {
+	Id      string              `json:"id,omitempty"`
+	Message string              `json:"message,omitempty"`
+	AutoTag *autotag.Suggestion `json:"auto_tag,omitempty"`
+}
+
+// autoTag fills in the `tags` and `language` arguments of a new quote that
+// are missing, when `auto_tag` is set. It returns what was suggested, or
+// nil when nothing was asked for.
+func autoTag(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest, args map[string]any) (*autotag.Suggestion, error) {
+	tagsGiven, languageGiven := hasArg(args, "tags"), hasArg(args, "language")
+	if !request.GetBool("auto_tag", false) || tagsGiven && languageGiven {
+		return nil, nil
+	}
+	s, err := autotag.Suggest(ctx, client.New(cfg), request.GetString("quote", ""), request.GetString("author", ""))
+	if err != nil {
+		return nil, err
+	}
+	// Only what was used is reported
+	if tagsGiven {
+		s.Tags = nil
+	} else if len(s.Tags) > 0 {
+		args["tags"] = strings.Join(s.Tags, ",")
+	}
+	if languageGiven {
+		s.Language = ""
+	} else if s.Language != "" {
+		args["language"] = s.Language
+	}
+	return &s, nil
+}
+
+// autoTagPatch is autoTag for patch_quote. The quote is fetched for the
+// text, tags and language it has; suggested tags are added to its tags
+// rather than replacing them, and the language is only filled in when it
+// has none or the text changes.
+func autoTagPatch(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest, args map[string]any) (*autotag.Suggestion, error) {
+	tagsGiven, languageGiven := hasArg(args, "tags"), hasArg(args, "language")
+	if !request.GetBool("auto_tag", false) || tagsGiven && languageGiven {
+		return nil, nil
+	}
+	c := client.New(cfg)
+	current, err := c.GetQuote(ctx, request.GetString("id", ""))
+	if err != nil {
+		return nil, err
+	}
+	text, author := current.Quote, current.Author
+	if hasArg(args, "quote") {
+		text = request.GetString("quote", "")
+	}
+	if hasArg(args, "author") {
+		author = request.GetString("author", "")
+	}
+	s, err := autotag.Suggest(ctx, c, text, author)
+	if err != nil {
+		return nil, err
+	}
+	if tagsGiven {
+		s.Tags = nil
+	} else if len(s.Tags) > 0 {
+		tags := append([]string{}, current.Tags...)
+		for _, t := range s.Tags {
+			if !containsFold(tags, t) {
+				tags = append(tags, t)
+			}
+		}
+		if len(tags) > len(current.Tags) {
+			args["tags"] = strings.Join(tags, ",")
+		}
+	}
+	if !languageGiven && s.Language != "" && (current.Language == "" || hasArg(args, "quote")) {
+		args["language"] = s.Language
+	} else {
+		s.Language = ""
+	}
+	return &s, nil
+}
+
+func hasArg(args map[string]any, name string) bool {
+	v, ok := args[name]
+	return ok && strings.TrimSpace(client.String(v)) != ""
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/backup"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// BackupSummary is the result of backup_account.
+type BackupSummary struct {
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	// Counts is the number of items backed up per kind.
+	Counts map[string]int `json:"counts"`
+	// MissingFiles is the number of backgrounds and fonts whose file is
+	// not in the archive.
+	MissingFiles int      `json:"missing_files"`
+	Warnings     []string `json:"warnings"`
+	// Cancelled is set when the call was cancelled before the backup
+	// finished. The archive holds what was backed up until then and its
+	// manifest is marked as cancelled.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+func Backup_accountHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := request.Params.Arguments.(map[string]any); !ok && request.Params.Arguments != nil {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		path, err = cfg.LocalPath(path)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Cannot write the backup archive", err), nil
+		}
+		m, err := backup.Create(ctx, cfg, path, backup.Options{Binaries: request.GetBool("binaries", true)})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Backup failed", err), nil
+		}
+
+		warnings := m.Warnings
+		if warnings == nil {
+			warnings = []string{}
+		}
+		missing := 0
+		for _, a := range append(append([]backup.Asset{}, m.Backgrounds...), m.Fonts...) {
+			if a.Missing != "" {
+				missing++
+			}
+		}
+		return models.ToolResult(BackupSummary{
+			Path:      path,
+			CreatedAt: m.CreatedAt,
+			Counts: map[string]int{
+				backup.KindQuotes:      len(m.Quotes),
+				backup.KindQshows:      len(m.Qshows),
+				backup.KindQOD:         len(m.QOD),
+				backup.KindBackgrounds: len(m.Backgrounds),
+				backup.KindFonts:       len(m.Fonts),
+			},
+			MissingFiles: missing,
+			Warnings:     warnings,
+			Cancelled:    m.Cancelled,
+		}), nil
+	}
+}
+
+func CreateBackup_accountTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("backup_account",
+		mcp.WithToolTitle("Back Up Account"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Back up the whole account to a single .tar.gz archive with a manifest: private quotes, qshows with their quotes, QOD definitions from the local registry, and uploaded backgrounds and fonts with their tags and files. Restore it with `restore_account`."),
+		mcp.WithString("path", mcp.Required(), mcp.Description("Archive file to write, e.g. backup.tar.gz, relative to the data directory (the working directory for the `backup` command). Not available in HTTP mode.")),
+		mcp.WithBoolean("binaries", mcp.Description("Include the background and font files. Defaults to true.")),
+		mcp.WithOutputSchema[BackupSummary](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Backup_accountHandler(cfg),
+	}
+}
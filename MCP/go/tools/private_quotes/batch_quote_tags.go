@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/batch"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// DefaultBatchSearchLimit is how many quotes a search filter resolves to
+// when no `limit` is given.
+const DefaultBatchSearchLimit = 100
+
+func Batch_quote_tagsHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		change, err := batch.TagChangeFromRequest(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dryRun := request.GetBool("dry_run", false)
+		c := client.New(cfg)
+
+		// Tags already known for an id, so renames and no-op changes can be
+		// planned without fetching the quote again.
+		known := map[string][]string{}
+		ids := batch.Dedupe(client.Strings(args["ids"]))
+		if len(ids) == 0 {
+			params := client.SearchParams(args)
+			if params == nil {
+				return mcp.NewToolResultError("Either `ids` or a search filter (category, author, query, language) is required"), nil
+			}
+			params.Set("private", "true")
+			if !params.Has("limit") {
+				params.Set("limit", fmt.Sprint(DefaultBatchSearchLimit))
+			}
+			quotes, err := c.SearchQuotes(ctx, params)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to resolve search filter", err), nil
+			}
+			for _, q := range quotes {
+				ids = append(ids, q.Id)
+				known[q.Id] = append([]string{}, q.Tags...)
+			}
+			ids = batch.Dedupe(ids)
+		}
+
+		if !dryRun {
+			summary := fmt.Sprintf("Change the tags of %d private quotes", len(ids))
+			if stop := confirm.Items(ctx, cfg.ConfirmPolicy, summary, ids, change.Describe()...); stop != nil {
+				return stop, nil
+			}
+		}
+
+		outcomes := batch.Run(ctx, ids, request.GetInt("concurrency", batch.DefaultConcurrency), func(ctx context.Context, id string) batch.Outcome {
+			current, ok := known[id]
+			if !ok && len(change.Rename) > 0 {
+				q, err := c.GetQuote(ctx, id)
+				if err != nil {
+					return batch.Outcome{ID: id, Status: batch.StatusFailed, Error: fmt.Sprintf("fetching quote: %v", err)}
+				}
+				current = append([]string{}, q.Tags...)
+			}
+			return batch.ApplyTags(ctx, c, batch.QuoteTagEndpoints, id, current, change, dryRun)
+		})
+
+		return models.ToolResult(batch.Summarize(outcomes, dryRun)), nil
+	}
+}
+
+func CreateBatch_quote_tagsTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("batch_quote_tags",
+		mcp.WithToolTitle("Batch Edit Quote Tags"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Add, remove or rename tags on many private quotes at once. Quotes are given as `ids` or selected with a search filter over the private collection. Returns a per-quote outcome summary."),
+		mcp.WithArray("ids", mcp.WithStringItems(), mcp.Description("Quote IDs to retag. When omitted, the search filter selects the quotes.")),
+		mcp.WithString("category", mcp.Description("Search filter: quote category")),
+		mcp.WithString("author", mcp.Description("Search filter: quote author")),
+		mcp.WithString("query", mcp.Description("Search filter: keyword to search for in the quote")),
+		mcp.WithString("language", mcp.Description("Search filter: language of the quote")),
+		mcp.WithNumber("limit", mcp.Description("Search filter: maximum number of quotes to select. Defaults to 100.")),
+		mcp.WithString("add", mcp.Description("Comma Separated tags to add")),
+		mcp.WithString("remove", mcp.Description("Comma Separated tags to remove")),
+		mcp.WithString("rename", mcp.Description("Comma Separated `old:new` tag pairs. Only quotes carrying the old tag are changed.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report the planned changes without applying them")),
+		mcp.WithNumber("concurrency", mcp.Description("How many quotes to update in parallel. Defaults to 4, at most 16.")),
+		mcp.WithOutputSchema[batch.Summary](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Batch_quote_tagsHandler(cfg),
+	}
+}
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+	"github.com/they-said-so-quotes-api/mcp-server/mirror"
+)
+
+// confirmTagsRemove asks to confirm removing tags from a quote, showing
+// the quote and the tags it keeps. Nothing is asked when it has none of
+// them.
+func confirmTagsRemove(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, false, func() (confirm.Change, error) {
+		id := request.GetString("id", "")
+		q, err := client.New(cfg).GetQuote(ctx, id)
+		if err != nil {
+			return confirm.Change{}, err
+		}
+		remove := client.Strings(request.GetString("tags", ""))
+		var removed, kept []string
+		for _, t := range q.Tags {
+			if containsFold(remove, t) {
+				removed = append(removed, t)
+			} else {
+				kept = append(kept, t)
+			}
+		}
+		if len(removed) == 0 {
+			return confirm.Change{}, nil
+		}
+		return confirm.Change{
+			Summary: fmt.Sprintf("Remove tags from quote %s: %s", id, strings.Join(removed, ", ")),
+			Details: []string{
+				fmt.Sprintf("Quote: %q", q.Quote),
+				"Tags kept: " + orNone(kept),
+			},
+		}, nil
+	})
+}
+
+// confirmPatch asks to confirm an update of a quote, showing each field
+// that changes. Nothing is asked when none does.
+func confirmPatch(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest, args map[string]any) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, false, func() (confirm.Change, error) {
+		id := request.GetString("id", "")
+		q, err := client.New(cfg).GetQuote(ctx, id)
+		if err != nil {
+			return confirm.Change{}, err
+		}
+		var fields []string
+		for _, f := range []struct{ name, old string }{
+			{"quote", q.Quote},
+			{"author", q.Author},
+			{"language", q.Language},
+			{"tags", strings.Join(q.Tags, ",")},
+		} {
+			if v, ok := args[f.name]; ok && client.String(v) != f.old {
+				fields = append(fields, confirm.Field(f.name, f.old, client.String(v)))
+			}
+		}
+		if len(fields) == 0 {
+			return confirm.Change{}, nil
+		}
+		return confirm.Change{Summary: fmt.Sprintf("Update quote %s", id), Details: fields}, nil
+	})
+}
+
+// confirmSync asks to confirm a sync, showing what a dry run of it would
+// push upstream and pull into local files. Nothing is asked when it would
+// change neither.
+func confirmSync(ctx context.Context, cfg *config.APIConfig, opts mirror.Options) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, true, func() (confirm.Change, error) {
+		opts.DryRun = true
+		report, err := mirror.Run(ctx, mirror.Kinds(cfg), opts)
+		if err != nil {
+			return confirm.Change{}, err
+		}
+		var pushed, pulled []string
+		for _, item := range report.Items {
+			name := item.Kind + " " + item.ID
+			if len(item.Pushed) > 0 {
+				pushed = append(pushed, fmt.Sprintf("%s: %s", name, strings.Join(item.Pushed, ", ")))
+			}
+			if len(item.Pulled) > 0 || len(item.Reverted) > 0 {
+				pulled = append(pulled, fmt.Sprintf("%s: %s", name, strings.Join(append(item.Pulled, item.Reverted...), ", ")))
+			}
+		}
+		if len(pushed) == 0 && len(pulled) == 0 {
+			return confirm.Change{}, nil
+		}
+		var details []string
+		if len(pushed) > 0 {
+			details = append(details, fmt.Sprintf("Push %d changes upstream:", len(pushed)))
+			details = append(details, confirm.List(pushed)...)
+		}
+		if len(pulled) > 0 {
+			details = append(details, fmt.Sprintf("Overwrite %d local files with upstream values:", len(pulled)))
+			details = append(details, confirm.List(pulled)...)
+		}
+		return confirm.Change{Summary: fmt.Sprintf("Sync %s with the account", opts.Dir), Details: details}, nil
+	})
+}
+
+func orNone(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/autotag"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// onDuplicateOption is the `on_duplicate` argument shared by put_quote and
+// post_quote.
+var onDuplicateOption = mcp.WithString("on_duplicate",
+	mcp.Enum(dedupe.Policies...),
+	mcp.Description("What to do when the private collection already has this quote (compared ignoring case, whitespace and punctuation): `allow` creates it anyway (default), `reject` fails, `warn` creates it and reports the existing one, `return_existing` returns the existing quote instead of creating one."),
+)
+
+// QuoteCreated is the result of put_quote and post_quote.
+type QuoteCreated struct {
+	// Id is the id of the created quote, or of the existing one when
+	// Duplicate is set.
+	Id string `json:"id,omitempty"`
+	// Duplicate is set when `on_duplicate` is `return_existing` and the
+	// quote was not created because ExistingQuote has the same text.
+	Duplicate        bool              `json:"duplicate,omitempty"`
+	ExistingQuote    *models.Quote     `json:"existing_quote,omitempty"`
+	DuplicateWarning *DuplicateWarning `json:"duplicate_warning,omitempty"`
+	// AutoTag reports the tags and language chosen with `auto_tag`.
+	AutoTag *autotag.Suggestion `json:"auto_tag,omitempty"`
+}
+
+// DuplicateWarning reports the existing quote with the same text when
+// `on_duplicate` is `warn`.
+type DuplicateWarning struct {
+	Message       string       `json:"message"`
+	ExistingQuote models.Quote `json:"existing_quote"`
+}
+
+// checkDuplicate applies the `on_duplicate` policy before a quote is
+// created. A non-nil result means the handler should return it instead of
+// creating the quote; a non-nil warning should be added to the response of
+// the created quote.
+func checkDuplicate(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest) (*mcp.CallToolResult, *DuplicateWarning) {
+	policy := request.GetString("on_duplicate", dedupe.PolicyAllow)
+	if policy == dedupe.PolicyAllow || policy == "" {
+		return nil, nil
+	}
+	switch policy {
+	case dedupe.PolicyReject, dedupe.PolicyWarn, dedupe.PolicyReturnExisting:
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid on_duplicate %q", policy)), nil
+	}
+
+	existing, err := dedupe.FindExisting(ctx, client.New(cfg), request.GetString("quote", ""))
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("Failed to check for duplicates", err), nil
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	switch policy {
+	case dedupe.PolicyReject:
+		return mcp.NewToolResultError(fmt.Sprintf("Duplicate of private quote %s: %q", existing.Id, existing.Quote)), nil
+	case dedupe.PolicyReturnExisting:
+		return models.ToolResult(QuoteCreated{Id: existing.Id, Duplicate: true, ExistingQuote: existing}), nil
+	}
+	return nil, &DuplicateWarning{
+		Message:       "A quote with the same text already exists in the private collection",
+		ExistingQuote: *existing,
+	}
+}
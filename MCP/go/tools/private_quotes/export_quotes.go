@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// ExportFormats lists the formats accepted by export_quotes.
+var ExportFormats = []string{"csv", "json", "jsonl", "markdown"}
+
+// DefaultExportMaxBytes caps the size of an export returned inline.
+const DefaultExportMaxBytes = 256 * 1024
+
+// ExportFilter narrows an export to quotes matching every non-empty field.
+type ExportFilter struct {
+	Tag      string
+	Author   string
+	Language string
+}
+
+// Match reports whether q passes the filter. Tag and author compare
+// case-insensitively; quotes without a language are treated as English,
+// the API default.
+func (f ExportFilter) Match(q models.Quote) bool {
+	if f.Author != "" && !strings.EqualFold(strings.TrimSpace(q.Author), strings.TrimSpace(f.Author)) {
+		return false
+	}
+	if f.Language != "" {
+		lang := q.Language
+		if lang == "" {
+			lang = "en"
+		}
+		if !strings.EqualFold(lang, f.Language) {
+			return false
+		}
+	}
+	if f.Tag != "" {
+		found := false
+		for _, tag := range q.Tags {
+			if strings.EqualFold(tag, f.Tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeQuotes renders quotes in one of ExportFormats.
+func EncodeQuotes(quotes []models.Quote, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "csv":
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"id", "quote", "author", "language", "tags", "length"})
+		for _, q := range quotes {
+			w.Write([]string{q.Id, q.Quote, q.Author, q.Language, strings.Join(q.Tags, ","), strconv.Itoa(q.Length)})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+	case "json":
+		if quotes == nil {
+			quotes = []models.Quote{}
+		}
+		data, err := json.MarshalIndent(quotes, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	case "jsonl":
+		enc := json.NewEncoder(&buf)
+		for _, q := range quotes {
+			if err := enc.Encode(q); err != nil {
+				return nil, err
+			}
+		}
+	case "markdown":
+		buf.WriteString("# Private quotes\n")
+		for _, q := range quotes {
+			buf.WriteString("\n")
+			for _, line := range strings.Split(q.Quote, "\n") {
+				fmt.Fprintf(&buf, "> %s\n", line)
+			}
+			if q.Author != "" {
+				fmt.Fprintf(&buf, ">\n> — %s\n", q.Author)
+			}
+			meta := []string{fmt.Sprintf("id: `%s`", q.Id)}
+			if q.Language != "" {
+				meta = append(meta, fmt.Sprintf("language: `%s`", q.Language))
+			}
+			if len(q.Tags) > 0 {
+				meta = append(meta, fmt.Sprintf("tags: `%s`", strings.Join(q.Tags, "`, `")))
+			}
+			fmt.Fprintf(&buf, "\n%s\n", strings.Join(meta, " · "))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q (expected one of %s)", format, strings.Join(ExportFormats, ", "))
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportSummary is the result of export_quotes and export_quotes_file. An
+// inline export is in Data; a file export was written to Path.
+type ExportSummary struct {
+	Path    string `json:"path,omitempty"`
+	Format  string `json:"format"`
+	Scanned int    `json:"scanned"`
+	Count   int    `json:"count"`
+	Bytes   int    `json:"bytes"`
+	Data    string `json:"data,omitempty"`
+	// Cancelled is set when the call was cancelled while the collection
+	// was walked. The export holds the quotes of the pages fetched until
+	// then.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+// String is the text shown next to the structured result.
+func (s ExportSummary) String() string {
+	where := "inline"
+	if s.Path != "" {
+		where = "to " + s.Path
+	}
+	text := fmt.Sprintf("Exported %d of %d private quotes as %s (%d bytes) %s.", s.Count, s.Scanned, s.Format, s.Bytes, where)
+	if s.Cancelled {
+		text += " The call was cancelled, so the export only covers the quotes fetched until then."
+	}
+	return text
+}
+
+// exportQuotes walks the private collection and encodes the quotes
+// matching the request's filter. A non-nil result reports a failure. When
+// the call is cancelled the quotes fetched so far are exported.
+func exportQuotes(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest) (ExportSummary, []byte, *mcp.CallToolResult) {
+	if _, ok := request.Params.Arguments.(map[string]any); !ok && request.Params.Arguments != nil {
+		return ExportSummary{}, nil, mcp.NewToolResultError("Invalid arguments object")
+	}
+	format := strings.ToLower(request.GetString("format", "json"))
+	filter := ExportFilter{
+		Tag:      request.GetString("tag", ""),
+		Author:   request.GetString("author", ""),
+		Language: request.GetString("language", ""),
+	}
+
+	all, err := client.New(cfg).ListPrivateQuotes(ctx, request.GetInt("page_size", client.DefaultPageSize))
+	cancelled := err != nil && ctx.Err() != nil
+	if err != nil && !cancelled {
+		return ExportSummary{}, nil, mcp.NewToolResultErrorFromErr(fmt.Sprintf("Failed to list private quotes after %d quotes", len(all)), err)
+	}
+	quotes := make([]models.Quote, 0, len(all))
+	for _, q := range all {
+		if filter.Match(q) {
+			quotes = append(quotes, q)
+		}
+	}
+
+	data, err := EncodeQuotes(quotes, format)
+	if err != nil {
+		return ExportSummary{}, nil, mcp.NewToolResultErrorFromErr("Failed to encode export", err)
+	}
+	return ExportSummary{Format: format, Scanned: len(all), Count: len(quotes), Bytes: len(data), Cancelled: cancelled}, data, nil
+}
+
+func Export_quotesHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		summary, data, stop := exportQuotes(ctx, cfg, request)
+		if stop != nil {
+			return stop, nil
+		}
+		if maxBytes := request.GetInt("max_bytes", DefaultExportMaxBytes); len(data) > maxBytes {
+			return mcp.NewToolResultError(fmt.Sprintf("Export of %d quotes is %d bytes, above the inline limit of %d bytes. Use `export_quotes_file` to write it to a file instead.", summary.Count, len(data), maxBytes)), nil
+		}
+		summary.Data = string(data)
+		return mcp.NewToolResultStructured(summary, summary.String()), nil
+	}
+}
+
+func Export_quotes_fileHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		path, err = cfg.LocalPath(path)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Cannot write the export file", err), nil
+		}
+		summary, data, stop := exportQuotes(ctx, cfg, request)
+		if stop != nil {
+			return stop, nil
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to create the export directory", err), nil
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to write export file", err), nil
+		}
+		summary.Path = path
+		return mcp.NewToolResultStructured(summary, summary.String()), nil
+	}
+}
+
+// exportOptions are the arguments shared by export_quotes and
+// export_quotes_file.
+var exportOptions = []mcp.ToolOption{
+	mcp.WithString("format", mcp.Enum(ExportFormats...), mcp.Description("Output format. Defaults to json.")),
+	mcp.WithString("tag", mcp.Description("Only export quotes carrying this tag")),
+	mcp.WithString("author", mcp.Description("Only export quotes by this author")),
+	mcp.WithString("language", mcp.Description("Only export quotes in this language")),
+	mcp.WithNumber("page_size", mcp.Description("How many quotes to request per page while walking the collection. Defaults to 50.")),
+	mcp.WithOutputSchema[ExportSummary](),
+}
+
+func CreateExport_quotesTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("export_quotes", append([]mcp.ToolOption{
+		mcp.WithToolTitle("Export Private Quotes"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Export the whole private quote collection by walking every page of `get_quote_list`, and return it inline as CSV, JSON, JSONL or Markdown in the `data` field. Use `export_quotes_file` for exports above `max_bytes`."),
+		mcp.WithNumber("max_bytes", mcp.Description("Largest export to return, in bytes. Defaults to 262144.")),
+	}, exportOptions...)...)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Export_quotesHandler(cfg),
+	}
+}
+
+func CreateExport_quotes_fileTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("export_quotes_file", append([]mcp.ToolOption{
+		mcp.WithToolTitle("Export Private Quotes to a File"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Export the whole private quote collection like `export_quotes`, and write it to a file on the server, replacing any file already there."),
+		mcp.WithString("path", mcp.Required(), mcp.Description("File to write the export to, relative to the data directory (the working directory for the `export` command). Not available in HTTP mode.")),
+	}, exportOptions...)...)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Export_quotes_fileHandler(cfg),
+	}
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// DuplicateReport is the result of find_duplicate_quotes.
+type DuplicateReport struct {
+	Scanned  int              `json:"scanned"`
+	Clusters []dedupe.Cluster `json:"clusters"`
+	// Duplicates is the number of quotes that could be removed, keeping
+	// one per cluster.
+	Duplicates int `json:"duplicates"`
+}
+
+func Find_duplicate_quotesHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, ok := request.Params.Arguments.(map[string]any); !ok && request.Params.Arguments != nil {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		c := client.New(cfg)
+		if request.GetBool("refresh", false) {
+			c.InvalidatePrivateQuotes()
+		}
+		quotes, err := c.CachedPrivateQuotes(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to list private quotes", err), nil
+		}
+
+		clusters := dedupe.Clusters(quotes, request.GetBool("match_author", false))
+		duplicates := 0
+		for _, cl := range clusters {
+			duplicates += len(cl.Quotes) - 1
+		}
+		return models.ToolResult(DuplicateReport{Scanned: len(quotes), Clusters: clusters, Duplicates: duplicates}), nil
+	}
+}
+
+func CreateFind_duplicate_quotesTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("find_duplicate_quotes",
+		mcp.WithToolTitle("Find Duplicate Quotes"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Report clusters of quotes in your private collection that have the same text once case, whitespace, curly quotes and punctuation are ignored."),
+		mcp.WithBoolean("match_author", mcp.Description("Only cluster quotes that also have the same author")),
+		mcp.WithBoolean("refresh", mcp.Description("Ignore the cached listing of the private collection and fetch it again")),
+		mcp.WithOutputSchema[DuplicateReport](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Find_duplicate_quotesHandler(cfg),
+	}
+}
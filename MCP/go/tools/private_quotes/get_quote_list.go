@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -31,7 +31,7 @@ func Get_quote_listHandler(cfg *config.APIConfig) func(ctx context.Context, requ
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/list%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -41,7 +41,7 @@ func Get_quote_listHandler(cfg *config.APIConfig) func(ctx context.Context, requ
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -55,27 +55,26 @@ func Get_quote_listHandler(cfg *config.APIConfig) func(ctx context.Context, requ
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(models.QuoteList{Quotes: client.Quotes(result), Total: client.Total(result)}), nil
 	}
 }
 
 func CreateGet_quote_listTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_quote_list",
+		mcp.WithToolTitle("List Private Quotes"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Get the list of quotes in your private collection."),
 		mcp.WithNumber("start", mcp.Description("Response is paged. This parameter controls where response starts the listing at")),
 		mcp.WithNumber("limit", mcp.Description("Response is paged. This parameter controls how many is returned in the result.")),
+		mcp.WithOutputSchema[models.QuoteList](),
 	)
 
 	return models.Tool{
@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/backup"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Migrate_accountHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		destToken, err := request.RequireString("destination_token")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		kinds := client.Strings(args["kinds"])
+		for _, kind := range kinds {
+			if err := oneOf("kind", kind, backup.KindNames); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		// Both accounts default to the configured one, so only what differs
+		// needs to be given. Other hosts are only reached for CLI commands,
+		// and never with the configured credentials.
+		srcURL := request.GetString("source_base_url", cfg.BaseURL)
+		dstURL := request.GetString("destination_base_url", cfg.BaseURL)
+		srcToken := request.GetString("source_token", "")
+		if (srcURL != cfg.BaseURL || dstURL != cfg.BaseURL) && !cfg.OtherHosts {
+			return mcp.NewToolResultError("Other API base URLs can only be given to the migrate command"), nil
+		}
+		if srcURL != cfg.BaseURL && srcToken == "" {
+			return mcp.NewToolResultError("source_token is required when source_base_url is not the configured API base URL"), nil
+		}
+		src := *cfg
+		src.BaseURL = srcURL
+		if srcToken != "" {
+			src.BearerToken, src.APIKey, src.BasicAuth = srcToken, "", ""
+		}
+		dst := *cfg
+		dst.BaseURL = dstURL
+		dst.BearerToken, dst.APIKey, dst.BasicAuth = destToken, "", ""
+
+		report, err := backup.Migrate(ctx, &src, &dst, request.GetBool("dry_run", false), kinds)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Migration failed", err), nil
+		}
+
+		return models.ToolResult(report), nil
+	}
+}
+
+func CreateMigrate_accountTool(cfg *config.APIConfig) models.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithToolTitle("Migrate Account"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Copy the private collection of one account into another. Quotes are compared by normalized text, qshows and QOD definitions by title, and backgrounds and fonts by file content; only what the destination lacks is created, with its tags. Qshows are filled with the migrated quotes. Returns the mapping from source to destination ids, with items already present listed under `reused`."),
+		mcp.WithString("destination_token", mcp.Required(), mcp.Description("Bearer token of the destination account")),
+		mcp.WithString("source_token", mcp.Description("Bearer token of the source account. Defaults to the configured credentials.")),
+		mcp.WithArray("kinds", mcp.WithStringItems(), mcp.Description("Limit the migration to these kinds: backgrounds, fonts, quotes, qshows, qod. Defaults to all.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report what would be copied without creating anything in the destination")),
+		mcp.WithOutputSchema[backup.Report](),
+	}
+	// The server only talks to its configured host; the migrate command
+	// can move a collection between hosts
+	if cfg.OtherHosts {
+		options = append(options,
+			mcp.WithString("destination_base_url", mcp.Description("API base URL of the destination. Defaults to the configured one.")),
+			mcp.WithString("source_base_url", mcp.Description("API base URL of the source, which requires source_token. Defaults to the configured one.")),
+		)
+	}
+	tool := mcp.NewTool("migrate_account", options...)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Migrate_accountHandler(cfg),
+	}
+}
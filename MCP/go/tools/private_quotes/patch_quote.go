@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -19,6 +19,13 @@ func Patch_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
+		suggestion, err := autoTagPatch(ctx, cfg, request, args)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to auto-tag the quote", err), nil
+		}
+		if stop := confirmPatch(ctx, cfg, request, args); stop != nil {
+			return stop, nil
+		}
 		queryParams := make([]string, 0)
 		if val, ok := args["id"]; ok {
 			queryParams = append(queryParams, fmt.Sprintf("id=%v", val))
@@ -40,7 +47,7 @@ func Patch_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PATCH", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -50,7 +57,7 @@ func Patch_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -64,30 +71,33 @@ func Patch_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		client.New(cfg).InvalidatePrivateQuotes()
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(QuoteUpdated{Message: strings.TrimSpace(string(body)), AutoTag: suggestion}), nil
 		}
+		status := client.StatusFromResult(result)
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(QuoteUpdated{Id: status.Id, Message: status.Message, AutoTag: suggestion}), nil
 	}
 }
 
 func CreatePatch_quoteTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_quote",
+		mcp.WithToolTitle("Update Private Quote"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Update a quote"),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Quote ID")),
 		mcp.WithString("quote", mcp.Description("Quote")),
 		mcp.WithString("author", mcp.Description("Quote Author")),
 		mcp.WithString("language", mcp.Description("Language. If not supplied an auto detection mechanism will be used to detect a language.")),
 		mcp.WithString("tags", mcp.Description("Comma Separated tags")),
+		autoTagOption,
+		mcp.WithOutputSchema[QuoteUpdated](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -31,7 +31,7 @@ func Post_quote_tags_addHandler(cfg *config.APIConfig) func(ctx context.Context,
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/tags/add%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("POST", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -41,7 +41,7 @@ func Post_quote_tags_addHandler(cfg *config.APIConfig) func(ctx context.Context,
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -55,27 +55,27 @@ func Post_quote_tags_addHandler(cfg *config.APIConfig) func(ctx context.Context,
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePost_quote_tags_addTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_quote_tags_add",
+		mcp.WithToolTitle("Add Quote Tags"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Add a tag to a given Quote."),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Quote ID")),
 		mcp.WithString("tags", mcp.Required(), mcp.Description("Comma Separated tags")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -19,6 +19,14 @@ func Put_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
+		stop, duplicateWarning := checkDuplicate(ctx, cfg, request)
+		if stop != nil {
+			return stop, nil
+		}
+		suggestion, err := autoTag(ctx, cfg, request, args)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to auto-tag the quote", err), nil
+		}
 		queryParams := make([]string, 0)
 		if val, ok := args["quote"]; ok {
 			queryParams = append(queryParams, fmt.Sprintf("quote=%v", val))
@@ -37,7 +45,7 @@ func Put_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PUT", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -47,7 +55,7 @@ func Put_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -61,29 +69,32 @@ func Put_quoteHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+		client.New(cfg).InvalidatePrivateQuotes()
+		created := QuoteCreated{DuplicateWarning: duplicateWarning, AutoTag: suggestion}
+		// The quote was created even when the answer is not JSON
+		if result, err := client.Decode(body); err == nil {
+			created.Id = client.ID(result)
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(created), nil
 	}
 }
 
 func CreatePut_quoteTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("put_quote",
+		mcp.WithToolTitle("Add Private Quote"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Add a new quote to your private collection."),
 		mcp.WithString("quote", mcp.Required(), mcp.Description("Quote")),
 		mcp.WithString("author", mcp.Description("Quote Author")),
 		mcp.WithString("tags", mcp.Description("Comma Separated tags")),
 		mcp.WithString("language", mcp.Description("Language. If not supplied an auto detection mechanism will be used to detect a language.")),
+		onDuplicateOption,
+		autoTagOption,
+		mcp.WithOutputSchema[QuoteCreated](),
 	)
 
 	return models.Tool{
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/backup"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Restore_accountHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		path, err = cfg.LocalPath(path)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Cannot read the backup archive", err), nil
+		}
+		opts := backup.RestoreOptions{
+			DryRun:       request.GetBool("dry_run", false),
+			SkipExisting: request.GetBool("skip_existing", true),
+			Kinds:        client.Strings(args["kinds"]),
+		}
+		for _, kind := range opts.Kinds {
+			if err := oneOf("kind", kind, backup.KindNames); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		report, err := backup.Restore(ctx, cfg, path, opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Restore failed", err), nil
+		}
+
+		return models.ToolResult(report), nil
+	}
+}
+
+func CreateRestore_accountTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("restore_account",
+		mcp.WithToolTitle("Restore Account"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Restore a `backup_account` archive into the current account, which may differ from the one backed up. New ids are mapped from the old ones, so qshows get their restored quotes. Returns the id mapping and everything that could not be restored."),
+		mcp.WithString("path", mcp.Required(), mcp.Description("Archive file written by `backup_account`, relative to the data directory (the working directory for the `restore` command). Not available in HTTP mode.")),
+		mcp.WithArray("kinds", mcp.WithStringItems(), mcp.Description("Limit the restore to these kinds: backgrounds, fonts, quotes, qshows, qod. Defaults to all.")),
+		mcp.WithBoolean("skip_existing", mcp.Description("Reuse quotes with the same text and qshows and QOD definitions with the same title instead of creating duplicates. Defaults to true.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report what would be restored without creating anything")),
+		mcp.WithOutputSchema[backup.Report](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Restore_accountHandler(cfg),
+	}
+}
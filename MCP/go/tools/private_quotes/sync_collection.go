@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/mirror"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// oneOf checks an enum argument.
+func oneOf(name, value string, allowed []string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported %s %q (want %s)", name, value, strings.Join(allowed, ", "))
+}
+
+func Sync_collectionHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		dir, err := request.RequireString("dir")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dir, err = cfg.LocalPath(dir)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Cannot sync with this directory", err), nil
+		}
+		opts := mirror.Options{
+			Dir:        dir,
+			Format:     strings.ToLower(request.GetString("format", mirror.FormatYAML)),
+			Direction:  strings.ToLower(request.GetString("direction", mirror.DirectionBoth)),
+			OnConflict: strings.ToLower(request.GetString("on_conflict", mirror.ConflictReport)),
+			DryRun:     request.GetBool("dry_run", false),
+			Kinds:      client.Strings(args["kinds"]),
+		}
+		for _, check := range []error{
+			oneOf("format", opts.Format, mirror.Formats),
+			oneOf("direction", opts.Direction, mirror.Directions),
+			oneOf("on_conflict", opts.OnConflict, mirror.ConflictPolicies),
+		} {
+			if check != nil {
+				return mcp.NewToolResultError(check.Error()), nil
+			}
+		}
+		for _, kind := range opts.Kinds {
+			if err := oneOf("kind", kind, mirror.KindNames); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		if !opts.DryRun {
+			if stop := confirmSync(ctx, cfg, opts); stop != nil {
+				return stop, nil
+			}
+		}
+		report, err := mirror.Run(ctx, mirror.Kinds(cfg), opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Sync failed", err), nil
+		}
+
+		return models.ToolResult(report), nil
+	}
+}
+
+func CreateSync_collectionTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("sync_collection",
+		mcp.WithToolTitle("Sync Collection"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Two-way sync of the private collection with a local directory: one YAML or JSON file per quote, qshow and QOD definition. Upstream changes are written to the files; local edits to quotes and qshows are pushed with `patch_quote` and `patch_qshow`. Fields changed on both sides since the last sync are reported as conflicts. Entities are never created or deleted upstream; QOD definitions are mirrored only."),
+		mcp.WithString("dir", mcp.Required(), mcp.Description("Directory to sync with, relative to the data directory (the working directory for the `sync` command). Created on first sync. Not available in HTTP mode.")),
+		mcp.WithString("format", mcp.Enum(mirror.Formats...), mcp.Description("Format of new files. Defaults to yaml; existing files keep their format.")),
+		mcp.WithString("direction", mcp.Enum(mirror.Directions...), mcp.Description("both (default), pull (never push) or push (never write files)")),
+		mcp.WithString("on_conflict", mcp.Enum(mirror.ConflictPolicies...), mcp.Description("report (default) keeps the local value and reports the conflict; local pushes the local value; remote takes the upstream value.")),
+		mcp.WithArray("kinds", mcp.WithStringItems(), mcp.Description("Limit the sync to these kinds: quotes, qshows, qod. Defaults to all.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report what would change without writing files or pushing")),
+		mcp.WithOutputSchema[mirror.Report](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Sync_collectionHandler(cfg),
+	}
+}
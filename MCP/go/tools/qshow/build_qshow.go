@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/batch"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// DefaultBuildSearchLimit is how many quotes a search spec selects when no
+// `limit` is given.
+const DefaultBuildSearchLimit = 10
+
+// BuiltQshow is the result of build_qshow.
+type BuiltQshow struct {
+	QshowResult
+	Added []string `json:"added"`
+}
+
+func Build_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		title, err := request.RequireString("title")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		rollback := request.GetBool("rollback", false)
+		c := client.New(cfg)
+
+		quoteIDs := batch.Dedupe(client.Strings(args["quote_ids"]))
+		if len(quoteIDs) == 0 {
+			params := client.SearchParams(args)
+			if params == nil {
+				return mcp.NewToolResultError("Either `quote_ids` or a search spec (category, author, query) is required"), nil
+			}
+			if !params.Has("limit") {
+				params.Set("limit", fmt.Sprint(DefaultBuildSearchLimit))
+			}
+			if request.GetBool("private", false) {
+				params.Set("private", "true")
+			}
+			quotes, err := c.SearchQuotes(ctx, params)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to search for quotes", err), nil
+			}
+			for _, q := range quotes {
+				quoteIDs = append(quoteIDs, q.Id)
+			}
+			quoteIDs = batch.Dedupe(quoteIDs)
+			if len(quoteIDs) == 0 {
+				return mcp.NewToolResultError("The search spec matched no quotes; nothing was created"), nil
+			}
+		}
+
+		qshowID, err := c.CreateQshow(ctx, title, request.GetString("description", ""), client.Strings(args["tags"]))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to create qshow", err), nil
+		}
+
+		added, failed := addQshowQuotes(ctx, c, qshowID, quoteIDs, rollback)
+		if len(failed) > 0 && rollback {
+			return rollbackQshow(ctx, c, qshowID, failed[0]), nil
+		}
+
+		return qshowSummaryResult(ctx, c, qshowID, &BuiltQshow{
+			QshowResult: QshowResult{Failed: failed},
+			Added:       added,
+		}), nil
+	}
+}
+
+func CreateBuild_qshowTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("build_qshow",
+		mcp.WithToolTitle("Build Qshow"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Create a qshow and fill it with quotes in one step. Quotes are given as `quote_ids` or selected with a search spec. Returns the finished qshow as `get_qshow_quotes` would."),
+		mcp.WithString("title", mcp.Required(), mcp.Description("Qshow title")),
+		mcp.WithString("description", mcp.Description("Qshow description")),
+		mcp.WithArray("tags", mcp.WithStringItems(), mcp.Description("Tags for the qshow")),
+		mcp.WithArray("quote_ids", mcp.WithStringItems(), mcp.Description("Quote IDs to add, in order. When omitted, the search spec selects the quotes.")),
+		mcp.WithString("category", mcp.Description("Search spec: quote category")),
+		mcp.WithString("author", mcp.Description("Search spec: quote author")),
+		mcp.WithString("query", mcp.Description("Search spec: keyword to search for in the quote")),
+		mcp.WithString("language", mcp.Description("Search spec: language of the quote")),
+		mcp.WithNumber("limit", mcp.Description("Search spec: number of quotes to add. Defaults to 10.")),
+		mcp.WithBoolean("private", mcp.Description("Search spec: search the private collection instead of public quotes")),
+		mcp.WithBoolean("rollback", mcp.Description("Delete the new qshow if any quote cannot be added. By default the qshow is kept and failures are reported.")),
+		mcp.WithOutputSchema[BuiltQshow](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Build_qshowHandler(cfg),
+	}
+}
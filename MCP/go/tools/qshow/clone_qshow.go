@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// ClonedQshow is the result of clone_qshow.
+type ClonedQshow struct {
+	QshowResult
+	SourceID string   `json:"source_id"`
+	Added    []string `json:"added"`
+}
+
+func Clone_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		sourceID, err := request.RequireString("id")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		c := client.New(cfg)
+
+		source, quotes, err := c.QshowQuotes(ctx, sourceID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to fetch source qshow", err), nil
+		}
+		title := request.GetString("title", "")
+		if title == "" {
+			title = source.Title
+		}
+		description := request.GetString("description", source.Description)
+		tags := client.Strings(args["tags"])
+		if _, ok := args["tags"]; !ok {
+			tags = source.Tags
+		}
+
+		qshowID, err := c.CreateQshow(ctx, title, description, tags)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to create qshow", err), nil
+		}
+		quoteIDs := make([]string, 0, len(quotes))
+		for _, q := range quotes {
+			quoteIDs = append(quoteIDs, q.Id)
+		}
+		rollback := request.GetBool("rollback", false)
+		added, failed := addQshowQuotes(ctx, c, qshowID, quoteIDs, rollback)
+		if len(failed) > 0 && rollback {
+			return rollbackQshow(ctx, c, qshowID, failed[0]), nil
+		}
+
+		return qshowSummaryResult(ctx, c, qshowID, &ClonedQshow{
+			QshowResult: QshowResult{Failed: failed},
+			SourceID:    sourceID,
+			Added:       added,
+		}), nil
+	}
+}
+
+func CreateClone_qshowTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("clone_qshow",
+		mcp.WithToolTitle("Clone Qshow"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Copy a qshow, public or private, into your private collection with the same quotes in the same order."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the qshow to clone")),
+		mcp.WithString("title", mcp.Description("Title for the copy. Defaults to the source title.")),
+		mcp.WithString("description", mcp.Description("Description for the copy. Defaults to the source description.")),
+		mcp.WithArray("tags", mcp.WithStringItems(), mcp.Description("Tags for the copy. Defaults to the source tags.")),
+		mcp.WithBoolean("rollback", mcp.Description("Delete the copy if any quote cannot be added. By default the copy is kept and failures are reported.")),
+		mcp.WithOutputSchema[ClonedQshow](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Clone_qshowHandler(cfg),
+	}
+}
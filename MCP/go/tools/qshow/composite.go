@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/progress"
+)
+
+// QshowResult holds the fields every composite qshow tool returns: the
+// finished qshow as get_qshow_quotes would return it, and the quotes that
+// could not be added or removed.
+type QshowResult struct {
+	QshowID string             `json:"qshow_id"`
+	Qshow   models.QshowQuotes `json:"qshow"`
+	Failed  []QshowFailure     `json:"failed,omitempty"`
+}
+
+func (r *QshowResult) qshowResult() *QshowResult { return r }
+
+// qshowSummary is a composite tool's result type, which embeds
+// QshowResult.
+type qshowSummary interface {
+	qshowResult() *QshowResult
+}
+
+// QshowFailure records a quote that could not be added to or removed from
+// a qshow.
+type QshowFailure struct {
+	QuoteID string `json:"quote_id"`
+	Error   string `json:"error"`
+}
+
+// addQshowQuotes adds quotes to a qshow one at a time so the qshow keeps
+// their order. With stopOnError it stops at the first failure.
+func addQshowQuotes(ctx context.Context, c *client.Client, qshowID string, quoteIDs []string, stopOnError bool) ([]string, []QshowFailure) {
+	added := []string{}
+	var failed []QshowFailure
+	progress.Expect(ctx, len(quoteIDs))
+	for _, quoteID := range quoteIDs {
+		err := ctx.Err()
+		if err == nil {
+			err = c.AddQshowQuote(ctx, qshowID, quoteID)
+		}
+		if err != nil {
+			failed = append(failed, QshowFailure{QuoteID: quoteID, Error: err.Error()})
+			progress.Addf(ctx, 1, "Could not add quote %s to qshow %s", quoteID, qshowID)
+			if stopOnError {
+				break
+			}
+			continue
+		}
+		added = append(added, quoteID)
+		progress.Addf(ctx, 1, "Added quote %s to qshow %s", quoteID, qshowID)
+	}
+	return added, failed
+}
+
+// rollbackQshow deletes a qshow created by a composite tool after one of
+// its quotes failed to be added, and describes the outcome.
+func rollbackQshow(ctx context.Context, c *client.Client, qshowID string, failure QshowFailure) *mcp.CallToolResult {
+	msg := fmt.Sprintf("Adding quote %s to qshow %s failed: %s.", failure.QuoteID, qshowID, failure.Error)
+	if err := c.DeleteQshow(context.WithoutCancel(ctx), qshowID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s Rolling back also failed, qshow %s was left in place: %v", msg, qshowID, err))
+	}
+	return mcp.NewToolResultError(msg + " The qshow was deleted.")
+}
+
+// qshowSummaryResult fetches the finished qshow into summary and returns
+// summary as the tool result. The qshow is fetched even when the call was
+// cancelled, so the summary of what was done is not lost.
+func qshowSummaryResult(ctx context.Context, c *client.Client, qshowID string, summary qshowSummary) *mcp.CallToolResult {
+	qshow, quotes, err := c.QshowQuotes(context.WithoutCancel(ctx), qshowID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("Qshow %s was updated but fetching it failed", qshowID), err)
+	}
+	r := summary.qshowResult()
+	r.QshowID = qshowID
+	r.Qshow = models.QshowQuotes{Qshow: qshow, Quotes: quotes}
+	return models.ToolResult(summary)
+}
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+)
+
+// confirmQuoteRemove asks to confirm removing a quote from a qshow,
+// showing the quote and how many remain. Nothing is asked when the qshow
+// does not hold it.
+func confirmQuoteRemove(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, false, func() (confirm.Change, error) {
+		id, quoteID := request.GetString("id", ""), request.GetString("quoteid", "")
+		qshow, quotes, err := client.New(cfg).QshowQuotes(ctx, id)
+		if err != nil {
+			return confirm.Change{}, err
+		}
+		for _, q := range quotes {
+			if q.Id == quoteID {
+				return confirm.Change{
+					Summary: fmt.Sprintf("Remove quote %s from qshow %q", quoteID, qshow.Title),
+					Details: []string{
+						fmt.Sprintf("Quote: %q", q.Quote),
+						fmt.Sprintf("%d of %d quotes remain", len(quotes)-1, len(quotes)),
+					},
+				}, nil
+			}
+		}
+		return confirm.Change{}, nil
+	})
+}
+
+// confirmPatch asks to confirm an update of a qshow, showing each field
+// that changes. Nothing is asked when none does.
+func confirmPatch(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest, args map[string]any) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, false, func() (confirm.Change, error) {
+		id := request.GetString("id", "")
+		qshow, _, err := client.New(cfg).QshowQuotes(ctx, id)
+		if err != nil {
+			return confirm.Change{}, err
+		}
+		var fields []string
+		if v, ok := args["title"]; ok && client.String(v) != qshow.Title {
+			fields = append(fields, confirm.Field("title", qshow.Title, client.String(v)))
+		}
+		if v, ok := args["description"]; ok && client.String(v) != qshow.Description {
+			fields = append(fields, confirm.Field("description", qshow.Description, client.String(v)))
+		}
+		if v, ok := args["tags"]; ok {
+			old, tags := strings.Join(qshow.Tags, ","), strings.Join(client.Strings(v), ",")
+			if tags != old {
+				fields = append(fields, confirm.Field("tags", old, tags))
+			}
+		}
+		if len(fields) == 0 {
+			return confirm.Change{}, nil
+		}
+		return confirm.Change{Summary: fmt.Sprintf("Update qshow %q", qshow.Title), Details: fields}, nil
+	})
+}
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -28,7 +28,7 @@ func Get_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qshow%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -38,7 +38,7 @@ func Get_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -52,27 +52,26 @@ func Get_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.QshowFromResult(result)), nil
 	}
 }
 
 func CreateGet_qshowTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_qshow",
+		mcp.WithToolTitle("Get Qshow"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Gets a details about a qshow.
 "),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Qshow ID")),
+		mcp.WithOutputSchema[models.Qshow](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -28,7 +28,7 @@ func Get_qshow_quotesHandler(cfg *config.APIConfig) func(ctx context.Context, re
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qshow/quotes%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -38,7 +38,7 @@ func Get_qshow_quotesHandler(cfg *config.APIConfig) func(ctx context.Context, re
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -52,26 +52,25 @@ func Get_qshow_quotesHandler(cfg *config.APIConfig) func(ctx context.Context, re
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(models.QshowQuotes{Qshow: client.QshowFromResult(result), Quotes: client.Quotes(result)}), nil
 	}
 }
 
 func CreateGet_qshow_quotesTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_qshow_quotes",
+		mcp.WithToolTitle("Get Qshow Quotes"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Get the quotes in a given Qshow."),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Qshow ID")),
+		mcp.WithOutputSchema[models.QshowQuotes](),
 	)
 
 	return models.Tool{
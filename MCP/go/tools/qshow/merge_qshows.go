@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/batch"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// mergeKey identifies a quote for deduplication: by normalized text when
+// byText is set, otherwise by id.
+func mergeKey(q models.Quote, byText bool) string {
+	if byText && q.Quote != "" {
+		return "text:" + dedupe.Normalize(q.Quote)
+	}
+	return "id:" + q.Id
+}
+
+// MergedQshow is the result of merge_qshows.
+type MergedQshow struct {
+	QshowResult
+	SourceIDs []string `json:"source_ids"`
+	// Created is set when the target qshow was created by the merge.
+	Created           bool     `json:"created"`
+	Added             []string `json:"added"`
+	DuplicatesSkipped int      `json:"duplicates_skipped"`
+}
+
+func Merge_qshowsHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		sourceIDs := batch.Dedupe(client.Strings(args["ids"]))
+		targetID := request.GetString("target_id", "")
+		title := request.GetString("title", "")
+		if targetID == "" && title == "" {
+			return mcp.NewToolResultError("`title` is required unless merging into an existing qshow with `target_id`"), nil
+		}
+		if len(sourceIDs) == 0 || (targetID == "" && len(sourceIDs) < 2) {
+			return mcp.NewToolResultError("`ids` must list at least two qshows, or one when `target_id` is given"), nil
+		}
+		byText := request.GetBool("dedupe_by_text", true)
+		c := client.New(cfg)
+
+		seen := map[string]bool{}
+		if targetID != "" {
+			_, existing, err := c.QshowQuotes(ctx, targetID)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to fetch target qshow", err), nil
+			}
+			for _, q := range existing {
+				seen[mergeKey(q, false)] = true
+				seen[mergeKey(q, byText)] = true
+			}
+		}
+
+		var quoteIDs []string
+		duplicates := 0
+		for _, id := range sourceIDs {
+			if id == targetID {
+				continue
+			}
+			_, quotes, err := c.QshowQuotes(ctx, id)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("Failed to fetch qshow %s", id), err), nil
+			}
+			for _, q := range quotes {
+				idKey, key := mergeKey(q, false), mergeKey(q, byText)
+				if seen[idKey] || seen[key] {
+					duplicates++
+					continue
+				}
+				seen[idKey], seen[key] = true, true
+				quoteIDs = append(quoteIDs, q.Id)
+			}
+		}
+
+		rollback := request.GetBool("rollback", false)
+		created := false
+		if targetID == "" {
+			var err error
+			targetID, err = c.CreateQshow(ctx, title, request.GetString("description", ""), client.Strings(args["tags"]))
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to create qshow", err), nil
+			}
+			created = true
+		}
+		added, failed := addQshowQuotes(ctx, c, targetID, quoteIDs, rollback && created)
+		if len(failed) > 0 && rollback && created {
+			return rollbackQshow(ctx, c, targetID, failed[0]), nil
+		}
+
+		return qshowSummaryResult(ctx, c, targetID, &MergedQshow{
+			QshowResult:       QshowResult{Failed: failed},
+			SourceIDs:         sourceIDs,
+			Created:           created,
+			Added:             added,
+			DuplicatesSkipped: duplicates,
+		}), nil
+	}
+}
+
+func CreateMerge_qshowsTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("merge_qshows",
+		mcp.WithToolTitle("Merge Qshows"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Merge the quotes of several qshows into a new private qshow, or into an existing one with `target_id`. Quotes appearing more than once are only added the first time."),
+		mcp.WithArray("ids", mcp.Required(), mcp.WithStringItems(), mcp.Description("IDs of the qshows to merge, in the order their quotes should appear")),
+		mcp.WithString("target_id", mcp.Description("Existing qshow to merge into. Quotes it already holds are skipped.")),
+		mcp.WithString("title", mcp.Description("Title of the new qshow (required without `target_id`)")),
+		mcp.WithString("description", mcp.Description("Description of the new qshow")),
+		mcp.WithArray("tags", mcp.WithStringItems(), mcp.Description("Tags for the new qshow")),
+		mcp.WithBoolean("dedupe_by_text", mcp.Description("Also treat quotes with the same text (ignoring case, whitespace and punctuation) as duplicates. Defaults to true.")),
+		mcp.WithBoolean("rollback", mcp.Description("Delete the new qshow if any quote cannot be added. Ignored with `target_id`.")),
+		mcp.WithOutputSchema[MergedQshow](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Merge_qshowsHandler(cfg),
+	}
+}
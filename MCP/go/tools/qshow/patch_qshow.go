@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -19,6 +19,9 @@ func Patch_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
+		if stop := confirmPatch(ctx, cfg, request, args); stop != nil {
+			return stop, nil
+		}
 		queryParams := make([]string, 0)
 		if val, ok := args["id"]; ok {
 			queryParams = append(queryParams, fmt.Sprintf("id=%v", val))
@@ -37,7 +40,7 @@ func Patch_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qshow%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PATCH", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PATCH", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -47,7 +50,7 @@ func Patch_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -61,29 +64,29 @@ func Patch_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePatch_qshowTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("patch_qshow",
+		mcp.WithToolTitle("Update Qshow"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Update an existing qshow."),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Qshow ID")),
 		mcp.WithString("title", mcp.Description("Qshow title")),
 		mcp.WithString("description", mcp.Description("Qshow description")),
 		mcp.WithArray("tags", mcp.Description("Tags for the qshow")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -34,7 +34,7 @@ func Put_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qshow%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PUT", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -44,7 +44,7 @@ func Put_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -58,28 +58,28 @@ func Put_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request m
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePut_qshowTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("put_qshow",
+		mcp.WithToolTitle("Create Qshow"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Create and add a new qshow to your private collection."),
 		mcp.WithString("title", mcp.Required(), mcp.Description("Qshow title")),
 		mcp.WithString("description", mcp.Description("Qshow description")),
 		mcp.WithArray("tags", mcp.Description("Tags for the qshow")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
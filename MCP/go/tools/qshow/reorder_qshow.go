@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/batch"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// reorderPlan returns the desired order of a qshow's quotes: the ids in
+// order first, then any current quotes not mentioned, in their current
+// order. It also returns how many leading quotes are already in place and
+// can stay untouched.
+func reorderPlan(current, order []string) (desired []string, keep int, err error) {
+	inShow := map[string]bool{}
+	for _, id := range current {
+		inShow[id] = true
+	}
+	var unknown []string
+	listed := map[string]bool{}
+	for _, id := range order {
+		if !inShow[id] {
+			unknown = append(unknown, id)
+			continue
+		}
+		listed[id] = true
+		desired = append(desired, id)
+	}
+	if len(unknown) > 0 {
+		return nil, 0, fmt.Errorf("quotes not in the qshow: %s", strings.Join(unknown, ", "))
+	}
+	for _, id := range current {
+		if !listed[id] {
+			desired = append(desired, id)
+		}
+	}
+	for keep < len(current) && current[keep] == desired[keep] {
+		keep++
+	}
+	return desired, keep, nil
+}
+
+// ReorderedQshow is the result of reorder_qshow.
+type ReorderedQshow struct {
+	QshowResult
+	Order []string `json:"order"`
+	// Unchanged is the number of leading quotes already in place.
+	Unchanged int `json:"unchanged"`
+	// Moved lists the quotes that were removed and added again.
+	Moved []string `json:"moved"`
+}
+
+func Reorder_qshowHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		qshowID, err := request.RequireString("id")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		order := batch.Dedupe(client.Strings(args["quote_ids"]))
+		if len(order) == 0 {
+			return mcp.NewToolResultError("`quote_ids` is required"), nil
+		}
+		c := client.New(cfg)
+
+		_, quotes, err := c.QshowQuotes(ctx, qshowID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to fetch qshow", err), nil
+		}
+		current := make([]string, 0, len(quotes))
+		for _, q := range quotes {
+			current = append(current, q.Id)
+		}
+		desired, keep, err := reorderPlan(current, order)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		moved := desired[keep:]
+		stop := confirm.Ask(ctx, cfg.ConfirmPolicy, len(moved) > 1, func() (confirm.Change, error) {
+			if len(moved) == 0 {
+				return confirm.Change{}, nil
+			}
+			return confirm.Change{
+				Summary: fmt.Sprintf("Reorder qshow %s by removing and re-adding %d of its %d quotes, in this order:", qshowID, len(moved), len(current)),
+				Details: confirm.List(moved),
+			}, nil
+		})
+		if stop != nil {
+			return stop, nil
+		}
+
+		// The API has no ordering call, so everything after the part that is
+		// already in place is removed and re-added in the new order. Any
+		// failure, cancellation included, stops it and the original order
+		// is put back, so no quote is dropped or added twice.
+		if err := ctx.Err(); err != nil {
+			return mcp.NewToolResultErrorFromErr("Cancelled before changing the qshow", err), nil
+		}
+		for _, id := range current[keep:] {
+			err := ctx.Err()
+			if err == nil {
+				err = c.RemoveQshowQuote(ctx, qshowID, id)
+			}
+			if err != nil {
+				return restoreQshowOrder(ctx, c, qshowID, current, QshowFailure{QuoteID: id, Error: "removing: " + err.Error()}), nil
+			}
+		}
+		readd, failed := addQshowQuotes(ctx, c, qshowID, desired[keep:], true)
+		if len(failed) > 0 {
+			return restoreQshowOrder(ctx, c, qshowID, current, QshowFailure{QuoteID: failed[0].QuoteID, Error: "adding: " + failed[0].Error}), nil
+		}
+
+		return qshowSummaryResult(ctx, c, qshowID, &ReorderedQshow{
+			Order:     desired,
+			Unchanged: keep,
+			Moved:     readd,
+		}), nil
+	}
+}
+
+// restoreQshowOrder puts back the original quotes of a qshow, in order,
+// after reordering it failed, and describes the outcome. The qshow is
+// fetched again, as a failed call may still have been applied upstream;
+// whatever follows the part still in place is removed and the original
+// quotes are added again.
+func restoreQshowOrder(ctx context.Context, c *client.Client, qshowID string, original []string, failure QshowFailure) *mcp.CallToolResult {
+	ctx = context.WithoutCancel(ctx)
+	msg := fmt.Sprintf("Reordering qshow %s failed on quote %s: %s.", qshowID, failure.QuoteID, failure.Error)
+	_, quotes, err := c.QshowQuotes(ctx, qshowID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s Fetching it to restore the original order also failed, so it may be missing quotes: %v", msg, err))
+	}
+	now := make([]string, 0, len(quotes))
+	for _, q := range quotes {
+		now = append(now, q.Id)
+	}
+	same := 0
+	for same < len(now) && same < len(original) && now[same] == original[same] {
+		same++
+	}
+	for _, id := range now[same:] {
+		if err := c.RemoveQshowQuote(ctx, qshowID, id); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s Restoring the original order also failed removing quote %s: %v. Its original order was: %s", msg, id, err, strings.Join(original, ", ")))
+		}
+	}
+	if _, failed := addQshowQuotes(ctx, c, qshowID, original[same:], true); len(failed) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("%s Restoring the original order also failed adding quote %s: %s. Its original order was: %s", msg, failed[0].QuoteID, failed[0].Error, strings.Join(original, ", ")))
+	}
+	return mcp.NewToolResultError(msg + " The original order was restored.")
+}
+
+func CreateReorder_qshowTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("reorder_qshow",
+		mcp.WithToolTitle("Reorder Qshow"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Put a qshow's quotes in a given order by rebuilding its membership. Quotes not listed keep their relative order after the listed ones. If any step fails or the call is cancelled, the original order is restored."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Qshow ID")),
+		mcp.WithArray("quote_ids", mcp.Required(), mcp.WithStringItems(), mcp.Description("Quote IDs in the desired order. Every ID must already be in the qshow.")),
+		mcp.WithOutputSchema[ReorderedQshow](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Reorder_qshowHandler(cfg),
+	}
+}
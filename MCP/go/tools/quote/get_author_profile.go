@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/dedupe"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+const (
+	DefaultProfileQuotes = 5
+	DefaultProfileImages = 3
+	// profileAuthorMatches is how many author search results are compared
+	// against the requested name.
+	profileAuthorMatches = 10
+)
+
+// AuthorProfile is the result of get_author_profile.
+type AuthorProfile struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Language string `json:"language"`
+	// Bio is left out when the detailed listing was not requested or is
+	// not available, or when the API knows nothing about the author.
+	Bio          *AuthorBio    `json:"bio,omitempty"`
+	NameVariants []NameVariant `json:"name_variants"`
+	// Languages lists the languages the author was found in.
+	Languages []string            `json:"languages"`
+	Quotes    []models.Quote      `json:"quotes,omitempty"`
+	Images    []models.QuoteImage `json:"images,omitempty"`
+	Warnings  []string            `json:"warnings"`
+}
+
+// AuthorBio is the detailed part of an author profile.
+type AuthorBio struct {
+	Occupation  string `json:"occupation,omitempty"`
+	Born        string `json:"born,omitempty"`
+	Dead        string `json:"dead,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NameVariant is the name an author is listed under in one language.
+type NameVariant struct {
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
+// bestAuthorMatch picks the search result that is the requested author:
+// an exact name match after normalization, then a slug match, then a
+// result containing the name, then the first result.
+func bestAuthorMatch(authors []models.Author, name string) *models.Author {
+	if len(authors) == 0 {
+		return nil
+	}
+	want := dedupe.Normalize(name)
+	slug := strings.ReplaceAll(want, " ", "-")
+	for i, a := range authors {
+		if dedupe.Normalize(a.Name) == want {
+			return &authors[i]
+		}
+	}
+	for i, a := range authors {
+		if a.Slug != "" && a.Slug == slug {
+			return &authors[i]
+		}
+	}
+	for i, a := range authors {
+		if strings.Contains(dedupe.Normalize(a.Name), want) {
+			return &authors[i]
+		}
+	}
+	return &authors[0]
+}
+
+// sameAuthor reports whether two search results, possibly from different
+// languages, are the same person. The API keeps one id and slug per
+// author across languages, so either is enough.
+func sameAuthor(a, b models.Author) bool {
+	return (a.Id != "" && a.Id == b.Id) || (a.Slug != "" && a.Slug == b.Slug)
+}
+
+func Get_author_profileHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		name, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		language := request.GetString("language", "en")
+		c := client.New(cfg)
+		warnings := []string{}
+
+		// Author and bio. The detailed listing is not available on every
+		// subscription; without it the profile is built from the plain one.
+		detailed := request.GetBool("detailed", true)
+		authors, err := c.SearchAuthors(ctx, name, language, detailed, profileAuthorMatches)
+		if detailed && client.IsTierError(err) {
+			detailed = false
+			warnings = append(warnings, "Detailed author information is not available on this subscription; the bio was left out.")
+			authors, err = c.SearchAuthors(ctx, name, language, false, profileAuthorMatches)
+		}
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to search for the author", err), nil
+		}
+		author := bestAuthorMatch(authors, name)
+		if author == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("No author matching %q in language %q", name, language)), nil
+		}
+
+		profile := AuthorProfile{
+			Id:       author.Id,
+			Name:     author.Name,
+			Slug:     author.Slug,
+			Language: language,
+		}
+		if detailed {
+			bio := AuthorBio{Occupation: author.Occupation, Born: author.Born, Dead: author.Dead, Description: author.Description}
+			if bio != (AuthorBio{}) {
+				profile.Bio = &bio
+			}
+		}
+
+		// Name variants. The same author may be listed under a different
+		// name in another language, so each language is searched for the
+		// matched id or slug.
+		languages := client.Strings(args["languages"])
+		if len(languages) == 0 {
+			if languages, err = c.Languages(ctx); err != nil {
+				warnings = append(warnings, fmt.Sprintf("Could not list languages: %v", err))
+			}
+		}
+		variants := []NameVariant{{Language: language, Name: author.Name}}
+		found := []string{language}
+		for _, lang := range languages {
+			if lang == language {
+				continue
+			}
+			queries := []string{author.Name}
+			if !strings.EqualFold(name, author.Name) {
+				queries = append(queries, name)
+			}
+			for _, query := range queries {
+				matches, err := c.SearchAuthors(ctx, query, lang, false, profileAuthorMatches)
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("Could not search authors in %q: %v", lang, err))
+					break
+				}
+				variant := ""
+				for _, m := range matches {
+					if sameAuthor(*author, m) {
+						variant = m.Name
+						break
+					}
+				}
+				if variant != "" {
+					variants = append(variants, NameVariant{Language: lang, Name: variant})
+					found = append(found, lang)
+					break
+				}
+			}
+		}
+		profile.NameVariants = variants
+		profile.Languages = found
+
+		// Top quotes, in the requested language.
+		quoteLimit := request.GetInt("quotes", DefaultProfileQuotes)
+		if quoteLimit > 0 {
+			quotes, err := c.SearchQuotes(ctx, url.Values{
+				"author":   {author.Name},
+				"language": {language},
+				"limit":    {strconv.Itoa(quoteLimit)},
+			})
+			if err != nil && !client.IsNotFound(err) {
+				warnings = append(warnings, fmt.Sprintf("Could not fetch quotes: %v", err))
+			}
+			profile.Quotes = quotes
+		}
+
+		// Sample images.
+		imageLimit := request.GetInt("images", DefaultProfileImages)
+		if imageLimit > 0 {
+			images, err := c.SearchQuoteImages(ctx, url.Values{"author": {author.Name}})
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Could not fetch images: %v", err))
+			}
+			if len(images) > imageLimit {
+				images = images[:imageLimit]
+			}
+			profile.Images = images
+		}
+		profile.Warnings = warnings
+
+		return models.ToolResult(profile), nil
+	}
+}
+
+func CreateGet_author_profileTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("get_author_profile",
+		mcp.WithToolTitle("Author Profile"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Gets everything known about an author in one object: the detailed bio (when the subscription level allows it), top quotes, the languages the author is listed in with the name used in each, and sample quote images."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Author name, or part of it")),
+		mcp.WithString("language", mcp.Description("Language the name is given in and the quotes are returned in. Defaults to en.")),
+		mcp.WithArray("languages", mcp.WithStringItems(), mcp.Description("Languages to look for name variants in. Defaults to every supported language.")),
+		mcp.WithNumber("quotes", mcp.Description("Number of quotes to include. Defaults to 5; 0 leaves them out.")),
+		mcp.WithNumber("images", mcp.Description("Number of sample images to include. Defaults to 3; 0 leaves them out.")),
+		mcp.WithBoolean("detailed", mcp.Description("Include the detailed bio. Defaults to true; left out with a warning when the subscription level does not allow it.")),
+		mcp.WithOutputSchema[AuthorProfile](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Get_author_profileHandler(cfg),
+	}
+}
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -37,7 +37,7 @@ func Get_quote_authors_popularHandler(cfg *config.APIConfig) func(ctx context.Co
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/authors/popular%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -47,7 +47,7 @@ func Get_quote_authors_popularHandler(cfg *config.APIConfig) func(ctx context.Co
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -61,30 +61,29 @@ func Get_quote_authors_popularHandler(cfg *config.APIConfig) func(ctx context.Co
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(models.AuthorList{Authors: client.Authors(result), Total: client.Total(result)}), nil
 	}
 }
 
 func CreateGet_quote_authors_popularTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_quote_authors_popular",
+		mcp.WithToolTitle("Popular Authors"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Gets a list of popular author names in the system. 
 "),
 		mcp.WithString("language", mcp.Description("Language. A same author may have quotes in two or more different languages. So for example 'Mahatma Gandhi' may be returned for language \"en\"(English), and \"மஹாத்மா காந்தி\" may be returned when the language is \"ta\" (Tamil).")),
 		mcp.WithBoolean("detailed", mcp.Description("Should return detailed author information such as `birthday`, `death date`, `occupation`, `description` etc. Only available at certain subscription levels.")),
 		mcp.WithNumber("start", mcp.Description("Response is paged. This parameter controls where response starts the listing at")),
 		mcp.WithNumber("limit", mcp.Description("Response is paged. This parameter controls how many is returned in the result. The maximum depends on the subscription level.")),
+		mcp.WithOutputSchema[models.AuthorList](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -40,7 +40,7 @@ func Get_quote_authors_searchHandler(cfg *config.APIConfig) func(ctx context.Con
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/authors/search%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -50,7 +50,7 @@ func Get_quote_authors_searchHandler(cfg *config.APIConfig) func(ctx context.Con
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -64,24 +64,22 @@ func Get_quote_authors_searchHandler(cfg *config.APIConfig) func(ctx context.Con
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(models.AuthorList{Authors: client.Authors(result), Total: client.Total(result)}), nil
 	}
 }
 
 func CreateGet_quote_authors_searchTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_quote_authors_search",
+		mcp.WithToolTitle("Search Authors"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Gets a list of author names in the system. 
 "),
 		mcp.WithString("query", mcp.Description("Text string to search for in author names")),
@@ -89,6 +87,7 @@ func CreateGet_quote_authors_searchTool(cfg *config.APIConfig) models.Tool {
 		mcp.WithBoolean("detailed", mcp.Description("Should return detailed author information such as `birthday`, `death date`, `occupation`, `description` etc. Only available at certain subscription levels.")),
 		mcp.WithNumber("start", mcp.Description("Response is paged. This parameter controls where response starts the listing at")),
 		mcp.WithNumber("limit", mcp.Description("Response is paged. This parameter controls how many is returned in the result. The maximum depends on the subscription level.")),
+		mcp.WithOutputSchema[models.AuthorList](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -28,7 +28,7 @@ func Get_quote_bookmark_toggleHandler(cfg *config.APIConfig) func(ctx context.Co
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/bookmark/toggle%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -38,7 +38,7 @@ func Get_quote_bookmark_toggleHandler(cfg *config.APIConfig) func(ctx context.Co
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -52,26 +52,25 @@ func Get_quote_bookmark_toggleHandler(cfg *config.APIConfig) func(ctx context.Co
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.ToggleFromResult(result)), nil
 	}
 }
 
 func CreateGet_quote_bookmark_toggleTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_quote_bookmark_toggle",
-		mcp.WithDescription("Toggle the user bookmark of the given Quote as a user of the API Key."),
+		mcp.WithToolTitle("Bookmark or Unbookmark Quote"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Toggle the user bookmark of the given Quote as a user of the API Key. Every call flips the state, even though it is a GET, so repeating a call undoes it."),
 		mcp.WithString("quote_id", mcp.Required(), mcp.Description("Quote ID")),
+		mcp.WithOutputSchema[models.Toggle](),
 	)
 
 	return models.Tool{
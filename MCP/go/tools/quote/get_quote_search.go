@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -52,7 +52,7 @@ func Get_quote_searchHandler(cfg *config.APIConfig) func(ctx context.Context, re
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/search%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -62,7 +62,7 @@ func Get_quote_searchHandler(cfg *config.APIConfig) func(ctx context.Context, re
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -76,24 +76,22 @@ func Get_quote_searchHandler(cfg *config.APIConfig) func(ctx context.Context, re
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result models.QuoteResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(models.QuoteList{Quotes: client.Quotes(result), Total: client.Total(result)}), nil
 	}
 }
 
 func CreateGet_quote_searchTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_quote_search",
+		mcp.WithToolTitle("Search Quotes"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Search for a `Quote` in They Said So platform. Optional `category` , `author`, `minlength`, `maxlength` params determines the filters applied while searching for the quote. "),
 		mcp.WithString("category", mcp.Description("Quote Category")),
 		mcp.WithString("author", mcp.Description("Quote Author")),
@@ -104,6 +102,7 @@ func CreateGet_quote_searchTool(cfg *config.APIConfig) models.Tool {
 		mcp.WithString("language", mcp.Description("Language of the Quote. The language must be supported in our system.")),
 		mcp.WithNumber("limit", mcp.Description("No of quotes to return. The max limit depends on the subscription level.")),
 		mcp.WithBoolean("sfw", mcp.Description("Should search only SFW (Safe For Work) quotes?")),
+		mcp.WithOutputSchema[models.QuoteList](),
 	)
 
 	return models.Tool{
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/batch"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// batchImageTagsHandler applies one tag change to a list of background or
+// font ids. The API does not return an image's tags, so every change is
+// applied as given: a rename removes the old tag and adds the new one on
+// every listed id.
+func batchImageTagsHandler(cfg *config.APIConfig, kind string, endpoints batch.TagEndpoints) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		change, err := batch.TagChangeFromRequest(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		ids := batch.Dedupe(client.Strings(args["ids"]))
+		if len(ids) == 0 {
+			return mcp.NewToolResultError("`ids` is required"), nil
+		}
+		dryRun := request.GetBool("dry_run", false)
+		c := client.New(cfg)
+		if !dryRun {
+			summary := fmt.Sprintf("Change the tags of %d %ss", len(ids), kind)
+			if stop := confirm.Items(ctx, cfg.ConfirmPolicy, summary, ids, change.Describe()...); stop != nil {
+				return stop, nil
+			}
+		}
+
+		outcomes := batch.Run(ctx, ids, request.GetInt("concurrency", batch.DefaultConcurrency), func(ctx context.Context, id string) batch.Outcome {
+			return batch.ApplyTags(ctx, c, endpoints, id, nil, change, dryRun)
+		})
+
+		return models.ToolResult(batch.Summarize(outcomes, dryRun)), nil
+	}
+}
+
+// batchImageTagsOptions are the arguments shared by the batch background
+// and font tag tools.
+func batchImageTagsOptions(kind string) []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithArray("ids", mcp.Required(), mcp.WithStringItems(), mcp.Description(kind+" IDs to retag")),
+		mcp.WithString("add", mcp.Description("Comma Separated tags to add")),
+		mcp.WithString("remove", mcp.Description("Comma Separated tags to remove")),
+		mcp.WithString("rename", mcp.Description("Comma Separated `old:new` tag pairs. The old tag is removed and the new one added on every listed id.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report the planned changes without applying them")),
+		mcp.WithNumber("concurrency", mcp.Description("How many ids to update in parallel. Defaults to 4, at most 16.")),
+		mcp.WithOutputSchema[batch.Summary](),
+	}
+}
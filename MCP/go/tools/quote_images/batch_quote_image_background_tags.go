@@ -0,0 +1,31 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/batch"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+func Batch_quote_image_background_tagsHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return batchImageTagsHandler(cfg, "background", batch.BackgroundTagEndpoints)
+}
+
+func CreateBatch_quote_image_background_tagsTool(cfg *config.APIConfig) models.Tool {
+	opts := append([]mcp.ToolOption{
+		mcp.WithToolTitle("Batch Edit Background Tags"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Add, remove or rename tags on many background images at once. Returns a per-image outcome summary."),
+	}, batchImageTagsOptions("Image")...)
+	tool := mcp.NewTool("batch_quote_image_background_tags", opts...)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Batch_quote_image_background_tagsHandler(cfg),
+	}
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/confirm"
+)
+
+// confirmTagsRemove asks to confirm removing tags from a background or
+// font. The API does not return an image's tags, so the tags are shown as
+// given.
+func confirmTagsRemove(ctx context.Context, cfg *config.APIConfig, request mcp.CallToolRequest, kind string) *mcp.CallToolResult {
+	return confirm.Ask(ctx, cfg.ConfirmPolicy, false, func() (confirm.Change, error) {
+		tags := client.Strings(request.GetString("tags", ""))
+		return confirm.Change{
+			Summary: fmt.Sprintf("Remove tags from %s %s: %s", kind, request.GetString("id", ""), strings.Join(tags, ", ")),
+		}, nil
+	})
+}
@@ -0,0 +1,331 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+const (
+	// DefaultCardSearchLimit is how many candidate quotes are considered.
+	DefaultCardSearchLimit = 20
+	// DefaultCardMaxLength keeps card text short enough to stay legible.
+	DefaultCardMaxLength = 200
+)
+
+// cardSizes are the named sizes accepted by `size`, as width x height.
+var cardSizes = map[string][2]int{
+	"square":    {1080, 1080},
+	"portrait":  {1080, 1350},
+	"landscape": {1200, 630},
+	"story":     {1080, 1920},
+}
+
+// parseCardSize reads a named size or an explicit `WIDTHxHEIGHT`.
+func parseCardSize(size string) (int, int, error) {
+	if wh, ok := cardSizes[strings.ToLower(size)]; ok {
+		return wh[0], wh[1], nil
+	}
+	if w, h, ok := strings.Cut(strings.ToLower(size), "x"); ok {
+		width, err1 := strconv.Atoi(strings.TrimSpace(w))
+		height, err2 := strconv.Atoi(strings.TrimSpace(h))
+		if err1 == nil && err2 == nil && width > 0 && height > 0 {
+			return width, height, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid size %q: use square, portrait, landscape, story or WIDTHxHEIGHT", size)
+}
+
+// cardSeed derives the default seed from the intent, so the same request
+// always produces the same card.
+func cardSeed(parts ...string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(parts, "\x00")))
+	return int64(h.Sum64() & 0x7fffffffffffffff)
+}
+
+// cardQuotes finds the candidate quotes for a card: by topic as a category,
+// then by topic as a keyword, or random quotes when neither topic nor
+// author is given.
+func cardQuotes(ctx context.Context, c *client.Client, topic, author, language string, maxLength int, private bool) ([]models.Quote, error) {
+	base := url.Values{"limit": {strconv.Itoa(DefaultCardSearchLimit)}}
+	if language != "" {
+		base.Set("language", language)
+	}
+	if topic == "" && author == "" {
+		result, err := c.Get(ctx, "/quote/random", base)
+		if err != nil {
+			return nil, err
+		}
+		return client.Quotes(result), nil
+	}
+	if author != "" {
+		base.Set("author", author)
+	}
+	if maxLength > 0 {
+		base.Set("maxlength", strconv.Itoa(maxLength))
+	}
+	if private {
+		base.Set("private", "true")
+	}
+	keys := []string{""}
+	if topic != "" {
+		keys = []string{"category", "query"}
+	}
+	for _, key := range keys {
+		params := url.Values{}
+		for k, v := range base {
+			params[k] = v
+		}
+		if key != "" {
+			params.Set(key, topic)
+		}
+		quotes, err := c.SearchQuotes(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(quotes) > 0 {
+			return quotes, nil
+		}
+	}
+	return nil, nil
+}
+
+// pickAsset searches the asset kind by each tag in turn and picks one from
+// the first tag that matches. Candidates are sorted so the pick depends
+// only on the seed, not on the order the API lists them in.
+func pickAsset(ctx context.Context, c *client.Client, rng *rand.Rand, kind string, tags []string) (*models.Asset, string, error) {
+	for _, tag := range tags {
+		assets, err := c.SearchAssets(ctx, kind, tag)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(assets) == 0 {
+			continue
+		}
+		sort.Slice(assets, func(i, j int) bool { return assets[i].Id < assets[j].Id })
+		return &assets[rng.Intn(len(assets))], tag, nil
+	}
+	return nil, "", nil
+}
+
+// QuoteCard is the result of create_quote_card.
+type QuoteCard struct {
+	Seed  int64        `json:"seed"`
+	Quote models.Quote `json:"quote"`
+	// Background and Font are nil when the default was used.
+	Background *models.Asset `json:"background"`
+	Font       *models.Asset `json:"font"`
+	// BackgroundTag and FontTag are the tags the assets were picked by.
+	BackgroundTag string            `json:"background_tag,omitempty"`
+	FontTag       string            `json:"font_tag,omitempty"`
+	Size          *CardSize         `json:"size,omitempty"`
+	Image         models.QuoteImage `json:"image"`
+	Warnings      []string          `json:"warnings"`
+}
+
+// CardSize is the requested size of a quote card. A zero dimension
+// follows the background.
+type CardSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func Create_quote_cardHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid arguments object"), nil
+		}
+		topic := request.GetString("topic", "")
+		author := request.GetString("author", "")
+		language := request.GetString("language", "")
+		mood := client.Strings(args["mood"])
+		c := client.New(cfg)
+
+		width, height := request.GetInt("width", 0), request.GetInt("height", 0)
+		if size := request.GetString("size", ""); size != "" {
+			w, h, err := parseCardSize(size)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			width, height = w, h
+		}
+
+		seed := cardSeed(topic, author, language, strings.Join(mood, ","), request.GetString("quote_id", ""))
+		if _, ok := args["seed"]; ok {
+			seed = int64(request.GetInt("seed", 0))
+		}
+		rng := rand.New(rand.NewSource(seed))
+		warnings := []string{}
+
+		// Quote
+		var quote models.Quote
+		if id := request.GetString("quote_id", ""); id != "" {
+			q, err := c.GetQuote(ctx, id)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to get quote", err), nil
+			}
+			quote = q
+		} else {
+			quotes, err := cardQuotes(ctx, c, topic, author, language, request.GetInt("max_length", DefaultCardMaxLength), request.GetBool("private", false))
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to search for quotes", err), nil
+			}
+			if len(quotes) == 0 {
+				return mcp.NewToolResultError("No quote matches the topic and author; nothing was rendered"), nil
+			}
+			sort.Slice(quotes, func(i, j int) bool { return quotes[i].Id < quotes[j].Id })
+			quote = quotes[rng.Intn(len(quotes))]
+		}
+
+		// Assets. Mood tags come first; the topic is the fallback tag.
+		tags := append([]string{}, mood...)
+		if topic != "" {
+			tags = append(tags, topic)
+		}
+		params := url.Values{"quote_id": {quote.Id}}
+		var background, font *models.Asset
+		var backgroundTag, fontTag string
+		if id := request.GetString("bgimage_id", ""); id != "" {
+			params.Set("bgimage_id", id)
+			background = &models.Asset{Id: id}
+		} else if color := request.GetString("bg_color", ""); color != "" {
+			params.Set("bg_color", color)
+		} else {
+			var err error
+			background, backgroundTag, err = pickAsset(ctx, c, rng, client.AssetBackground, tags)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to search for backgrounds", err), nil
+			}
+			if background != nil {
+				params.Set("bgimage_id", background.Id)
+			} else if len(tags) > 0 {
+				warnings = append(warnings, "No background matches the mood tags or topic; the default background was used.")
+			}
+		}
+		if id := request.GetString("font_id", ""); id != "" {
+			params.Set("font_id", id)
+			font = &models.Asset{Id: id}
+		} else {
+			var err error
+			font, fontTag, err = pickAsset(ctx, c, rng, client.AssetFont, mood)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to search for fonts", err), nil
+			}
+			if font != nil {
+				params.Set("font_id", font.Id)
+			} else if len(mood) > 0 {
+				warnings = append(warnings, "No font matches the mood tags; the default font was used.")
+			}
+		}
+
+		// Rendering
+		if width > 0 {
+			params.Set("width", strconv.Itoa(width))
+		}
+		if height > 0 {
+			params.Set("height", strconv.Itoa(height))
+		}
+		for _, key := range []string{"text_color", "text_size", "halign", "valign"} {
+			if v := request.GetString(key, ""); v != "" {
+				params.Set(key, v)
+			}
+		}
+		for _, key := range []string{"branding", "include_transparent_layer"} {
+			if _, ok := args[key]; ok {
+				params.Set(key, strconv.FormatBool(request.GetBool(key, false)))
+			}
+		}
+		imageID, err := c.CreateQuoteImage(ctx, params)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Failed to render quote image", err), nil
+		}
+		image, err := c.GetQuoteImage(ctx, imageID)
+		if err != nil {
+			image = models.QuoteImage{Id: imageID, QuoteID: quote.Id}
+			warnings = append(warnings, fmt.Sprintf("Image rendered but its details could not be fetched: %v", err))
+		}
+
+		card := QuoteCard{
+			Seed:          seed,
+			Quote:         quote,
+			Background:    background,
+			Font:          font,
+			BackgroundTag: backgroundTag,
+			FontTag:       fontTag,
+			Image:         image,
+		}
+		if width > 0 || height > 0 {
+			card.Size = &CardSize{Width: width, Height: height}
+		}
+
+		var data []byte
+		if request.GetBool("include_image", true) {
+			data, err = c.QuoteImageData(ctx, imageID)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Image rendered but could not be downloaded: %v", err))
+			} else if !strings.HasPrefix(http.DetectContentType(data), "image/") {
+				warnings = append(warnings, "Image rendered but the download was not an image file.")
+				data = nil
+			}
+		}
+		card.Warnings = warnings
+
+		result := models.ToolResult(card)
+		if data != nil && !result.IsError {
+			result.Content = append(result.Content, mcp.NewImageContent(base64.StdEncoding.EncodeToString(data), http.DetectContentType(data)))
+		}
+		return result, nil
+	}
+}
+
+func CreateCreate_quote_cardTool(cfg *config.APIConfig) models.Tool {
+	tool := mcp.NewTool("create_quote_card",
+		mcp.WithToolTitle("Create Quote Card"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+		mcp.WithDescription("Create a quote image from intent in one step: finds a quote by topic and author, picks a background and a font matching the mood tags, then renders the image with `put_quote_image`. Selection is deterministic for the same arguments; pass `seed` to get a different pick. Returns the quote, the chosen assets, the image details and the rendered image."),
+		mcp.WithString("topic", mcp.Description("Topic of the quote. Searched as a category, then as a keyword. Also the fallback tag for backgrounds.")),
+		mcp.WithString("author", mcp.Description("Quote author")),
+		mcp.WithString("language", mcp.Description("Language of the quote")),
+		mcp.WithString("quote_id", mcp.Description("Use this quote instead of searching for one")),
+		mcp.WithBoolean("private", mcp.Description("Search the private collection instead of public quotes")),
+		mcp.WithNumber("max_length", mcp.Description("Maximum quote length in characters. Defaults to 200.")),
+		mcp.WithArray("mood", mcp.WithStringItems(), mcp.Description("Mood tags used to search backgrounds and fonts, in order of preference (e.g. calm, bold)")),
+		mcp.WithString("size", mcp.Description("square (1080x1080), portrait (1080x1350), landscape (1200x630), story (1080x1920) or WIDTHxHEIGHT. By default the size of the background is used.")),
+		mcp.WithNumber("width", mcp.Description("Image Width. Ignored when `size` is given.")),
+		mcp.WithNumber("height", mcp.Description("Image Height. Ignored when `size` is given.")),
+		mcp.WithNumber("seed", mcp.Description("Seed for the quote, background and font picks. Defaults to one derived from the other arguments.")),
+		mcp.WithString("bgimage_id", mcp.Description("Use this background image instead of picking one")),
+		mcp.WithString("bg_color", mcp.Description("Use a background color instead of picking a background image")),
+		mcp.WithString("font_id", mcp.Description("Use this font instead of picking one")),
+		mcp.WithString("text_color", mcp.Description("Text Color")),
+		mcp.WithString("text_size", mcp.Description("Text/font size")),
+		mcp.WithString("halign", mcp.Description("Horizontal text Alignment Value")),
+		mcp.WithString("valign", mcp.Description("Vertical text Alignment Value")),
+		mcp.WithBoolean("branding", mcp.Description("Disable They Said So branding (Only available in certain subscription levels. Ignored in other levels)")),
+		mcp.WithBoolean("include_transparent_layer", mcp.Description("Should include a transparent layer between the text and the background image?")),
+		mcp.WithBoolean("include_image", mcp.Description("Attach the rendered image to the result. Defaults to true.")),
+		mcp.WithOutputSchema[QuoteCard](),
+	)
+
+	return models.Tool{
+		Definition: tool,
+		Handler:    Create_quote_cardHandler(cfg),
+	}
+}
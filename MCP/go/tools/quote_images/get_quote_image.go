@@ -2,12 +2,13 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -31,7 +32,7 @@ func Get_quote_imageHandler(cfg *config.APIConfig) func(ctx context.Context, req
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/image%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -41,7 +42,7 @@ func Get_quote_imageHandler(cfg *config.APIConfig) func(ctx context.Context, req
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -55,28 +56,49 @@ func Get_quote_imageHandler(cfg *config.APIConfig) func(ctx context.Context, req
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// A binary download is the image file itself
+			if mimeType := http.DetectContentType(body); strings.HasPrefix(mimeType, "image/") {
+				out := models.ToolResult(models.QuoteImage{Id: client.String(args["id"])})
+				out.Content = append(out.Content, mcp.NewImageContent(base64.StdEncoding.EncodeToString(body), mimeType))
+				return out, nil
+			}
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
+		}
+		image := client.QuoteImageFromResult(result)
+		if image.Id == "" {
+			image.Id = client.String(args["id"])
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		out := models.ToolResult(image)
+		// The inline image is also shown as an image, as a download is. It
+		// may come as a data URI.
+		encoded := image.Image
+		if _, after, ok := strings.Cut(encoded, ";base64,"); ok {
+			encoded = after
+		}
+		if data, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(data) > 0 {
+			if mimeType := http.DetectContentType(data); strings.HasPrefix(mimeType, "image/") {
+				out.Content = append(out.Content, mcp.NewImageContent(encoded, mimeType))
+			}
+		}
+		return out, nil
 	}
 }
 
 func CreateGet_quote_imageTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_quote_image",
+		mcp.WithToolTitle("Get Quote Image"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Gets a Quote image for a given id. Response can be an image file as a binary or a base64 encoded contents wrapped in json. `TODO`
 "),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Quote Image id")),
 		mcp.WithBoolean("binary", mcp.Description("Should the response be a direct file download of the image or a base64 encoded image file wrapped in json?")),
+		mcp.WithOutputSchema[models.QuoteImage](),
 	)
 
 	return models.Tool{
@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -19,6 +19,9 @@ func Post_quote_image_background_tags_removeHandler(cfg *config.APIConfig) func(
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
+		if stop := confirmTagsRemove(ctx, cfg, request, "background"); stop != nil {
+			return stop, nil
+		}
 		queryParams := make([]string, 0)
 		if val, ok := args["id"]; ok {
 			queryParams = append(queryParams, fmt.Sprintf("id=%v", val))
@@ -31,7 +34,7 @@ func Post_quote_image_background_tags_removeHandler(cfg *config.APIConfig) func(
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/image/background/tags/remove%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("POST", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -41,7 +44,7 @@ func Post_quote_image_background_tags_removeHandler(cfg *config.APIConfig) func(
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -55,27 +58,27 @@ func Post_quote_image_background_tags_removeHandler(cfg *config.APIConfig) func(
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePost_quote_image_background_tags_removeTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("post_quote_image_background_tags_remove",
+		mcp.WithToolTitle("Remove Background Tags"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Remove a tag from a given Image."),
 		mcp.WithString("id", mcp.Required(), mcp.Description("Image ID")),
 		mcp.WithString("tags", mcp.Required(), mcp.Description("Comma Separated tags")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
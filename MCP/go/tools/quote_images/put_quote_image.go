@@ -2,12 +2,12 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -61,7 +61,7 @@ func Put_quote_imageHandler(cfg *config.APIConfig) func(ctx context.Context, req
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/quote/image%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("PUT", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "PUT", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -71,7 +71,7 @@ func Put_quote_imageHandler(cfg *config.APIConfig) func(ctx context.Context, req
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -85,24 +85,23 @@ func Put_quote_imageHandler(cfg *config.APIConfig) func(ctx context.Context, req
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result map[string]interface{}
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			// The change was made even when the answer is not JSON
+			return models.ToolResult(models.Status{Message: strings.TrimSpace(string(body))}), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(client.StatusFromResult(result)), nil
 	}
 }
 
 func CreatePut_quote_imageTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("put_quote_image",
+		mcp.WithToolTitle("Render Quote Image"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Create a new quote image for a given quote. Choose background colors/images , choose different font styles and generate a beautiful quote image. Did you just had a feeling of being a god or what?!
 "),
 		mcp.WithString("quote_id", mcp.Required(), mcp.Description("Quote id")),
@@ -117,6 +116,7 @@ func CreatePut_quote_imageTool(cfg *config.APIConfig) models.Tool {
 		mcp.WithNumber("height", mcp.Description("Image Height(By default this takes the height of the background image)")),
 		mcp.WithBoolean("branding", mcp.Description("Disable They Said So branding (Only available in certain subscription levels. Ignored in other levels)")),
 		mcp.WithBoolean("include_transparent_layer", mcp.Description("Should include a transparent layer between the text and the background image? This helps when the background image is bright and obscures the text.")),
+		mcp.WithOutputSchema[models.Status](),
 	)
 
 	return models.Tool{
@@ -2,14 +2,15 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 
+	"github.com/they-said-so-quotes-api/mcp-server/client"
 	"github.com/they-said-so-quotes-api/mcp-server/config"
 	"github.com/they-said-so-quotes-api/mcp-server/models"
+	"github.com/they-said-so-quotes-api/mcp-server/qodstore"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -19,6 +20,17 @@ func Get_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 		if !ok {
 			return mcp.NewToolResultError("Invalid arguments object"), nil
 		}
+		// A private QOD can be named by title; the id comes from the local registry
+		if title := client.String(args["title"]); title != "" && client.String(args["id"]) == "" {
+			def, err := qodstore.Open(cfg).Find(client.New(cfg).Account(), title)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Failed to read QOD definition registry", err), nil
+			}
+			if def == nil || def.ID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("No QOD definition id known for title %q. Use `list_qod_definitions` to see the known definitions.", title)), nil
+			}
+			args["id"] = def.ID
+		}
 		queryParams := make([]string, 0)
 		if val, ok := args["category"]; ok {
 			queryParams = append(queryParams, fmt.Sprintf("category=%v", val))
@@ -34,7 +46,7 @@ func Get_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 			queryString = "?" + strings.Join(queryParams, "&")
 		}
 		url := fmt.Sprintf("%s/qod%s", cfg.BaseURL, queryString)
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Failed to create request", err), nil
 		}
@@ -44,7 +56,7 @@ func Get_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 		}
 		req.Header.Set("Accept", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.HTTPClient.Do(req)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Request failed", err), nil
 		}
@@ -58,29 +70,33 @@ func Get_qodHandler(cfg *config.APIConfig) func(ctx context.Context, request mcp
 		if resp.StatusCode >= 400 {
 			return mcp.NewToolResultError(fmt.Sprintf("API error: %s", body)), nil
 		}
-		// Use properly typed response
-		var result models.QODResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			// Fallback to raw text if unmarshaling fails
-			return mcp.NewToolResultText(string(body)), nil
-		}
-
-		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		result, err := client.Decode(body)
 		if err != nil {
-			return mcp.NewToolResultErrorFromErr("Failed to format JSON", err), nil
+			return mcp.NewToolResultErrorFromErr("Failed to decode response", err), nil
+		}
+		qods := client.QODs(result)
+		if len(qods) == 0 {
+			return mcp.NewToolResultError("No quote of the day in the response"), nil
 		}
 
-		return mcp.NewToolResultText(string(prettyJSON)), nil
+		return models.ToolResult(qods[0]), nil
 	}
 }
 
 func CreateGet_qodTool(cfg *config.APIConfig) models.Tool {
 	tool := mcp.NewTool("get_qod",
+		mcp.WithToolTitle("Quote of the Day"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
 		mcp.WithDescription("Gets `Quote of the Day` (QOD). Optional `category` param determines the category of returned quote of the day
 "),
 		mcp.WithString("category", mcp.Description("QOD Category (Used in public QOD only)")),
 		mcp.WithString("language", mcp.Description("Language of the QOD. The language must be supported in our QOD system.")),
 		mcp.WithString("id", mcp.Description("QOD defition id (Used in private QOD only)")),
+		mcp.WithString("title", mcp.Description("Title of a private QOD definition created or updated through this server. Resolved to its id from the local registry.")),
+		mcp.WithOutputSchema[models.QOD](),
 	)
 
 	return models.Tool{
@@ -0,0 +1,79 @@
+// Package toolsets names the groups of tools the server can expose, so
+// deployments can offer only some of them, and parses selections of them.
+package toolsets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Toolsets, one per package under tools.
+const (
+	Quotes        = "quotes"
+	QOD           = "qod"
+	PrivateQuotes = "private_quotes"
+	PrivateQOD    = "private_qod"
+	Qshow         = "qshow"
+	Images        = "images"
+)
+
+// All lists every toolset, in the order their tools are registered.
+var All = []string{Quotes, QOD, PrivateQuotes, PrivateQOD, Qshow, Images}
+
+// Parse reads a comma-separated selection of toolsets. Names enable a
+// toolset and names prefixed with "-" disable one; a selection that only
+// disables starts from all of them, as does "all". An empty selection
+// enables all toolsets.
+func Parse(spec string) ([]string, error) {
+	var enable, disable []string
+	for _, name := range strings.Split(strings.ToLower(spec), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		name, off := strings.CutPrefix(name, "-")
+		if name == "all" {
+			if off {
+				return nil, fmt.Errorf("cannot disable all toolsets")
+			}
+			enable = append(enable, All...)
+			continue
+		}
+		if !slices.Contains(All, name) {
+			return nil, fmt.Errorf("unknown toolset %q: expected %s", name, strings.Join(All, ", "))
+		}
+		if off {
+			disable = append(disable, name)
+		} else {
+			enable = append(enable, name)
+		}
+	}
+	if len(enable) == 0 {
+		enable = All
+	}
+
+	out := []string{}
+	for _, name := range All {
+		if slices.Contains(enable, name) && !slices.Contains(disable, name) {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+// Narrow applies a selection on top of the enabled toolsets: it can only
+// disable more of them, never enable one that is off.
+func Narrow(enabled []string, spec string) ([]string, error) {
+	selected, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	out := []string{}
+	for _, name := range selected {
+		if slices.Contains(enabled, name) {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
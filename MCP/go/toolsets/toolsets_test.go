@@ -0,0 +1,71 @@
+package toolsets
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{spec: "", want: All},
+		{spec: " , ", want: All},
+		{spec: "all", want: All},
+		{spec: "qod,quotes", want: []string{Quotes, QOD}},
+		{spec: " Quotes , QOD ", want: []string{Quotes, QOD}},
+		{spec: "quotes,quotes", want: []string{Quotes}},
+		{spec: "-images", want: []string{Quotes, QOD, PrivateQuotes, PrivateQOD, Qshow}},
+		{spec: "all,-private_quotes,-private_qod", want: []string{Quotes, QOD, Qshow, Images}},
+		{spec: "quotes,qod,-qod", want: []string{Quotes}},
+		{spec: "-quotes,-qod,-private_quotes,-private_qod,-qshow,-images", want: []string{}},
+		{spec: "bogus", wantErr: true},
+		{spec: "quotes,-bogus", wantErr: true},
+		{spec: "-all", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v, want an error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestNarrow(t *testing.T) {
+	tests := []struct {
+		enabled []string
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{enabled: []string{Quotes, QOD}, spec: "", want: []string{Quotes, QOD}},
+		{enabled: []string{Quotes, QOD}, spec: "all", want: []string{Quotes, QOD}},
+		{enabled: []string{Quotes, QOD}, spec: "qod", want: []string{QOD}},
+		{enabled: []string{Quotes, QOD}, spec: "-quotes", want: []string{QOD}},
+		// A selection never enables a toolset that is off.
+		{enabled: []string{Quotes, QOD}, spec: "quotes,private_quotes", want: []string{Quotes}},
+		{enabled: []string{Quotes}, spec: "images", want: []string{}},
+		{enabled: All, spec: "-images", want: []string{Quotes, QOD, PrivateQuotes, PrivateQOD, Qshow}},
+		{enabled: All, spec: "bogus", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Narrow(tt.enabled, tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Narrow(%v, %q) = %v, want an error", tt.enabled, tt.spec, got)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("Narrow(%v, %q) = %v, %v, want %v", tt.enabled, tt.spec, got, err, tt.want)
+		}
+	}
+}
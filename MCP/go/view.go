@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/they-said-so-quotes-api/mcp-server/capabilities"
+	"github.com/they-said-so-quotes-api/mcp-server/capstore"
+	"github.com/they-said-so-quotes-api/mcp-server/client"
+	"github.com/they-said-so-quotes-api/mcp-server/config"
+	"github.com/they-said-so-quotes-api/mcp-server/models"
+)
+
+// view is what the session of a request is shown: the tools its
+// configuration and capabilities allow, and the prompts and resource
+// templates that go with them. Their handlers use that configuration.
+type view struct {
+	cfg       *config.APIConfig
+	caps      capabilities.Set
+	tools     []models.Tool
+	prompts   []models.Prompt
+	templates []models.ResourceTemplate
+}
+
+func newView(cfg *config.APIConfig, caps capabilities.Set) *view {
+	tools := GetAll(cfg, caps)
+	return &view{
+		cfg:       cfg,
+		caps:      caps,
+		tools:     tools,
+		prompts:   GetAllPrompts(cfg, tools),
+		templates: GetAllResourceTemplates(cfg, caps),
+	}
+}
+
+type viewKey struct{}
+
+// viewCache keeps the view of the requests of a context, so the filters,
+// hooks and handlers a request goes through work it out once. It is worked
+// out again when the capabilities changed, as they may between the
+// requests of a STDIO session.
+type viewCache struct {
+	mu   sync.Mutex
+	view *view
+}
+
+// withConfig returns a copy of ctx carrying cfg as the configuration of
+// its requests.
+func withConfig(ctx context.Context, cfg *config.APIConfig) context.Context {
+	return context.WithValue(config.NewContext(ctx, cfg), viewKey{}, &viewCache{})
+}
+
+// viewOf returns the view of the request of ctx. A request without a
+// configuration is shown nothing.
+func viewOf(ctx context.Context, store *capstore.Store) *view {
+	cfg := config.FromContext(ctx)
+	if cfg == nil {
+		return &view{}
+	}
+	c := client.New(cfg)
+	caps := store.Get(ctx, c.Account(), c.Capabilities)
+	cache, ok := ctx.Value(viewKey{}).(*viewCache)
+	if !ok {
+		return newView(cfg, caps)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.view == nil || !maps.Equal(cache.view.caps, caps) {
+		cache.view = newView(cfg, caps)
+	}
+	return cache.view
+}
+
+func (v *view) tool(name string) (models.Tool, bool) {
+	for _, tool := range v.tools {
+		if tool.Definition.Name == name {
+			return tool, true
+		}
+	}
+	return models.Tool{}, false
+}
+
+func (v *view) prompt(name string) (models.Prompt, bool) {
+	for _, prompt := range v.prompts {
+		if prompt.Definition.Name == name {
+			return prompt, true
+		}
+	}
+	return models.Prompt{}, false
+}
+
+func (v *view) template(uriTemplate string) (models.ResourceTemplate, bool) {
+	for _, template := range v.templates {
+		if template.Definition.URITemplate.Raw() == uriTemplate {
+			return template, true
+		}
+	}
+	return models.ResourceTemplate{}, false
+}
+
+// filterTools keeps the tools of the view, with the definitions it has
+// for them, whose arguments may differ.
+func (v *view) filterTools(tools []mcp.Tool) []mcp.Tool {
+	var out []mcp.Tool
+	for _, tool := range tools {
+		if t, ok := v.tool(tool.Name); ok {
+			out = append(out, t.Definition)
+		}
+	}
+	return out
+}
+
+func (v *view) filterPrompts(prompts []mcp.Prompt) []mcp.Prompt {
+	var out []mcp.Prompt
+	for _, prompt := range prompts {
+		if p, ok := v.prompt(prompt.Name); ok {
+			out = append(out, p.Definition)
+		}
+	}
+	return out
+}
+
+func (v *view) filterTemplates(templates []mcp.ResourceTemplate) []mcp.ResourceTemplate {
+	var out []mcp.ResourceTemplate
+	for _, template := range templates {
+		if t, ok := v.template(template.URITemplate.Raw()); ok {
+			out = append(out, t.Definition)
+		}
+	}
+	return out
+}
+
+// register adds to s every tool, prompt and resource template of v. Their
+// handlers run those of the view of each request, so v only needs to be
+// the widest view any request can have.
+func register(s *server.MCPServer, store *capstore.Store, v *view) {
+	tools := make([]server.ServerTool, 0, len(v.tools))
+	for _, tool := range v.tools {
+		tools = append(tools, server.ServerTool{Tool: tool.Definition, Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := viewOf(ctx, store).tool(request.Params.Name)
+			if !ok {
+				return nil, fmt.Errorf("tool '%s' not found: %w", request.Params.Name, server.ErrToolNotFound)
+			}
+			return tool.Handler(ctx, request)
+		}})
+	}
+	s.SetTools(tools...)
+
+	var prompts []server.ServerPrompt
+	for _, prompt := range v.prompts {
+		prompts = append(prompts, server.ServerPrompt{Prompt: prompt.Definition, Handler: func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			prompt, ok := viewOf(ctx, store).prompt(request.Params.Name)
+			if !ok {
+				return nil, fmt.Errorf("prompt '%s' not found: %w", request.Params.Name, server.ErrPromptNotFound)
+			}
+			return prompt.Handler(ctx, request)
+		}})
+	}
+	s.SetPrompts(prompts...)
+
+	var templates []server.ServerResourceTemplate
+	for _, template := range v.templates {
+		uriTemplate := template.Definition.URITemplate.Raw()
+		templates = append(templates, server.ServerResourceTemplate{Template: template.Definition, Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			template, ok := viewOf(ctx, store).template(uriTemplate)
+			if !ok {
+				return nil, fmt.Errorf("%s: %w", request.Params.URI, server.ErrResourceNotFound)
+			}
+			return template.Handler(ctx, request)
+		}})
+	}
+	s.SetResourceTemplates(templates...)
+}